@@ -104,6 +104,33 @@ func (c *APIClient) Login(email, password string) (string, error) {
 	return cookie, nil
 }
 
+// GetCurrentUser returns the signed-in user
+func (c *APIClient) GetCurrentUser() (*CurrentUser, error) {
+	resp, err := c.get("/api/auth/me")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get current user failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		User *CurrentUser `json:"user"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode current user: %w", err)
+	}
+
+	if result.User == nil {
+		return nil, fmt.Errorf("no user in response")
+	}
+
+	return result.User, nil
+}
+
 // CreateSeries creates a new series
 func (c *APIClient) CreateSeries(name, description string) (*Series, error) {
 	payload := map[string]string{
@@ -191,6 +218,50 @@ func (c *APIClient) CreateBoard(name, seriesID string) (*BoardState, error) {
 	return result.Board, nil
 }
 
+// GetCloneSources returns the boards the clone dialog offers as a source
+// for boardID: those in its own series, then the latest of each other series
+func (c *APIClient) GetCloneSources(boardID string) ([]CloneSource, error) {
+	resp, err := c.get(fmt.Sprintf("/api/boards/%s/clone-sources", boardID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get clone sources failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Data struct {
+			CurrentSeries []CloneSource `json:"currentSeries"`
+			OtherSeries   []CloneSource `json:"otherSeries"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode clone sources: %w", err)
+	}
+
+	return append(result.Data.CurrentSeries, result.Data.OtherSeries...), nil
+}
+
+// CloneBoard copies sourceID's columns, scenes and settings into boardID,
+// which must have no columns or scenes yet
+func (c *APIClient) CloneBoard(boardID, sourceID string) error {
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/clone", boardID), map[string]string{"sourceId": sourceID})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("clone board failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // SetupBoardTemplate sets up a board with a template
 func (c *APIClient) SetupBoardTemplate(boardID, template string) error {
 	payload := map[string]string{
@@ -299,6 +370,44 @@ func (c *APIClient) GetBoard(boardID string) (*BoardState, error) {
 	return result.Board, nil
 }
 
+// GetPresence returns the users present on the board
+func (c *APIClient) GetPresence(boardID string) (*PresenceData, error) {
+	resp, err := c.get(fmt.Sprintf("/api/boards/%s/presence", boardID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get presence failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result PresenceData
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode presence: %w", err)
+	}
+
+	return &result, nil
+}
+
+// UpdatePresence refreshes the user's presence on the board, as the web
+// client does in answer to a presence_ping
+func (c *APIClient) UpdatePresence(boardID, activity string) error {
+	resp, err := c.put(fmt.Sprintf("/api/boards/%s/presence", boardID), map[string]string{"activity": activity})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("update presence failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // CreateCard creates a new card
 func (c *APIClient) CreateCard(boardID, columnID, content string) (*Card, error) {
 	payload := map[string]string{
@@ -351,9 +460,29 @@ func (c *APIClient) MoveCard(cardID, columnID string) error {
 	return nil
 }
 
-// VoteOnCard votes on a card
-func (c *APIClient) VoteOnCard(cardID string) error {
-	resp, err := c.post(fmt.Sprintf("/api/cards/%s/vote", cardID), map[string]string{})
+// UpdateCard replaces a card's content
+func (c *APIClient) UpdateCard(cardID, content string) error {
+	payload := map[string]string{
+		"content": content,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/cards/%s", cardID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("update card failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// DeleteCard deletes a card
+func (c *APIClient) DeleteCard(cardID string) error {
+	resp, err := c.delete(fmt.Sprintf("/api/cards/%s", cardID))
 	if err != nil {
 		return err
 	}
@@ -361,12 +490,39 @@ func (c *APIClient) VoteOnCard(cardID string) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("vote failed: %d - %s", resp.StatusCode, string(body))
+		return fmt.Errorf("delete card failed: %d - %s", resp.StatusCode, string(body))
 	}
 
 	return nil
 }
 
+// VoteOnCard spends one of the user's votes on a card, reporting false if
+// the user has none left. The card's new vote total is returned when the
+// server includes it.
+func (c *APIClient) VoteOnCard(cardID string) (bool, *int, error) {
+	resp, err := c.post(fmt.Sprintf("/api/cards/%s/vote", cardID), map[string]int{"delta": 1})
+	if err != nil {
+		return false, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		var result struct {
+			Card struct {
+				VoteCount *int `json:"voteCount"`
+			} `json:"card"`
+		}
+		json.Unmarshal(body, &result) // Leaves VoteCount nil if the total is missing
+		return true, result.Card.VoteCount, nil
+	case resp.StatusCode == http.StatusBadRequest && strings.Contains(string(body), "No votes remaining"):
+		return false, nil, nil
+	}
+
+	return false, nil, fmt.Errorf("vote failed: %d - %s", resp.StatusCode, string(body))
+}
+
 // GroupCards groups multiple cards together
 func (c *APIClient) GroupCards(boardID string, cardIDs []string, groupID string) error {
 	payload := map[string]interface{}{
@@ -408,55 +564,1108 @@ func (c *APIClient) GroupCardOnto(cardID, targetCardID string) error {
 	return nil
 }
 
-// Helper methods for HTTP operations
+// UngroupCard takes a card out of its group (facilitator only)
+func (c *APIClient) UngroupCard(cardID string) error {
+	resp, err := c.delete(fmt.Sprintf("/api/cards/%s/group", cardID))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-func (c *APIClient) get(path string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", c.baseURL+path, nil)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("ungroup card failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// ChangeScene switches the board's current scene (facilitator only)
+func (c *APIClient) ChangeScene(boardID, sceneID string) error {
+	payload := map[string]string{
+		"sceneId": sceneID,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/boards/%s/scene", boardID), payload)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return c.httpClient.Do(req)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("change scene failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
 }
 
-func (c *APIClient) post(path string, payload interface{}) (*http.Response, error) {
-	body, err := json.Marshal(payload)
+// StartTimer starts a countdown timer on the board and returns its state (facilitator only)
+func (c *APIClient) StartTimer(boardID string, durationSeconds int) (*TimerState, error) {
+	payload := map[string]interface{}{
+		"duration": durationSeconds,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/timer", boardID), payload)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	req, err := http.NewRequest("POST", c.baseURL+path, bytes.NewReader(body))
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("start timer failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return decodeTimer(resp)
+}
+
+// ExtendTimer adds seconds to the running timer, or starts one if none is running (facilitator only)
+func (c *APIClient) ExtendTimer(boardID string, addSeconds int) (*TimerState, error) {
+	payload := map[string]interface{}{
+		"addSeconds": addSeconds,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/boards/%s/timer", boardID), payload)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	return c.httpClient.Do(req)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("extend timer failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return decodeTimer(resp)
 }
 
-func (c *APIClient) put(path string, payload interface{}) (*http.Response, error) {
-	body, err := json.Marshal(payload)
+// decodeTimer reads the timer state from a timer start or extend response
+func decodeTimer(resp *http.Response) (*TimerState, error) {
+	var result struct {
+		Timer *TimerState `json:"timer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode timer response: %w", err)
+	}
+
+	if result.Timer == nil {
+		return nil, fmt.Errorf("no timer in response")
+	}
+
+	return result.Timer, nil
+}
+
+// VoteTimer votes on the running timer: "A" for more time, "B" to finish.
+// timerID is the timer's start timestamp.
+func (c *APIClient) VoteTimer(boardID, timerID, choice string) error {
+	payload := map[string]string{
+		"boardId": boardID,
+		"timerId": timerID,
+		"choice":  choice,
+	}
+
+	resp, err := c.post("/api/timer/vote", payload)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer resp.Body.Close()
 
-	req, err := http.NewRequest("PUT", c.baseURL+path, bytes.NewReader(body))
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("timer vote failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// StopTimer stops the board's timer (facilitator only)
+func (c *APIClient) StopTimer(boardID string) error {
+	resp, err := c.delete(fmt.Sprintf("/api/boards/%s/timer", boardID))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("stop timer failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// SelectCard selects the card being presented in a scene (facilitator only)
+func (c *APIClient) SelectCard(sceneID, cardID string) error {
+	payload := map[string]string{
+		"card_id": cardID,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/scenes/%s/select-card", sceneID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("select card failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// SetPresentFilter changes how a present mode scene orders its cards (facilitator only)
+func (c *APIClient) SetPresentFilter(sceneID, filterType string) error {
+	payload := map[string]string{
+		"type": filterType,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/scenes/%s/present-filter", sceneID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("set present filter failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetPresentData fetches the board as present mode shows it to this user
+func (c *APIClient) GetPresentData(boardID string) (*PresentData, error) {
+	resp, err := c.get(fmt.Sprintf("/api/boards/%s/present-data", boardID))
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	return c.httpClient.Do(req)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get present data failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var data PresentData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("decode present data: %w", err)
+	}
+
+	return &data, nil
 }
 
-func (c *APIClient) patch(path string, payload interface{}) (*http.Response, error) {
-	body, err := json.Marshal(payload)
+// GetUserVotes returns the user's own votes on the board, with the board-wide
+// totals when the current scene shows votes
+func (c *APIClient) GetUserVotes(boardID string) (*UserVotes, error) {
+	resp, err := c.get(fmt.Sprintf("/api/boards/%s/user-votes", boardID))
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	req, err := http.NewRequest("PATCH", c.baseURL+path, bytes.NewReader(body))
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get user votes failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		UserVotingData struct {
+			VotesByCard map[string]int `json:"votes_by_card"`
+		} `json:"user_voting_data"`
+		VotingStats    VotingStats    `json:"voting_stats"`
+		AllVotesByCard map[string]int `json:"all_votes_by_card"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode user votes: %w", err)
+	}
+
+	return &UserVotes{
+		VotesByCard:    result.UserVotingData.VotesByCard,
+		AllVotesByCard: result.AllVotesByCard,
+		Stats:          result.VotingStats,
+	}, nil
+}
+
+// GetVotingStats returns the board-wide voting totals
+func (c *APIClient) GetVotingStats(boardID string) (*VotingStats, error) {
+	resp, err := c.get(fmt.Sprintf("/api/boards/%s/voting-stats", boardID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get voting stats failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		VotingStats VotingStats `json:"voting_stats"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode voting stats: %w", err)
+	}
+
+	return &result.VotingStats, nil
+}
+
+// IncreaseVoteAllocation gives every user one more vote and returns the new
+// allocation (facilitator only)
+func (c *APIClient) IncreaseVoteAllocation(boardID string) (int, error) {
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/votes/increase-allocation", boardID), map[string]string{})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("increase vote allocation failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		NewAllocation int `json:"newAllocation"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decode vote allocation: %w", err)
+	}
+
+	return result.NewAllocation, nil
+}
+
+// ClearVotes removes all votes on the board and resets the allocation (facilitator only)
+func (c *APIClient) ClearVotes(boardID string) error {
+	resp, err := c.delete(fmt.Sprintf("/api/boards/%s/votes/clear", boardID))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("clear votes failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// AddComment adds a comment to a card
+func (c *APIClient) AddComment(cardID, content string) (*Comment, error) {
+	payload := map[string]interface{}{
+		"card_id": cardID,
+		"content": content,
+	}
+
+	resp, err := c.post("/api/comments", payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("add comment failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Comment *Comment `json:"comment"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode comment response: %w", err)
+	}
+
+	if result.Comment == nil {
+		return nil, fmt.Errorf("no comment in response")
+	}
+
+	return result.Comment, nil
+}
+
+// GetComments lists the comments on a card, newest first
+func (c *APIClient) GetComments(cardID string) ([]Comment, error) {
+	resp, err := c.get(fmt.Sprintf("/api/cards/%s/comments", cardID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get comments failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Comments []Comment `json:"comments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode comments: %w", err)
+	}
+
+	return result.Comments, nil
+}
+
+// DeleteComment deletes a comment (author, facilitator or admin)
+func (c *APIClient) DeleteComment(commentID string) error {
+	resp, err := c.delete(fmt.Sprintf("/api/comments/%s", commentID))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete comment failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// ToggleAgreement promotes a comment to an agreement or demotes it (facilitator only)
+func (c *APIClient) ToggleAgreement(commentID string, isAgreement bool) error {
+	payload := map[string]interface{}{
+		"is_agreement": isAgreement,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/comments/%s/toggle-agreement", commentID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("toggle agreement failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetAgreements lists the board's agreements, both standalone and promoted comments
+func (c *APIClient) GetAgreements(boardID string) ([]Agreement, error) {
+	resp, err := c.get(fmt.Sprintf("/api/boards/%s/agreements", boardID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get agreements failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Agreements []Agreement `json:"agreements"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode agreements: %w", err)
+	}
+
+	return result.Agreements, nil
+}
+
+// CreateAgreement adds a standalone agreement to the board (facilitator only)
+func (c *APIClient) CreateAgreement(boardID, content string) error {
+	payload := map[string]string{
+		"content": content,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/agreements", boardID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("create agreement failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// AcquireNotesLock asks for the edit lock on a card's notes. It returns
+// false without an error when another user holds the lock.
+func (c *APIClient) AcquireNotesLock(cardID string) (bool, error) {
+	resp, err := c.post(fmt.Sprintf("/api/cards/%s/notes/lock", cardID), map[string]string{})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusForbidden:
+		io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	return false, fmt.Errorf("acquire notes lock failed: %d - %s", resp.StatusCode, string(body))
+}
+
+// ReleaseNotesLock gives up the edit lock on a card's notes without saving.
+// It reports whether the caller still held the lock; only then does the
+// server announce the release.
+func (c *APIClient) ReleaseNotesLock(cardID string) (bool, error) {
+	resp, err := c.delete(fmt.Sprintf("/api/cards/%s/notes/lock", cardID))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("release notes lock failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("decode notes lock release: %w", err)
+	}
+
+	return result.Success, nil
+}
+
+// UpdateNotes saves a card's notes, which also releases the caller's lock
+func (c *APIClient) UpdateNotes(cardID, content string) error {
+	payload := map[string]string{
+		"content": content,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/cards/%s/notes", cardID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("update notes failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CreateScene adds a scene to the end of the board's scene list (facilitator only)
+func (c *APIClient) CreateScene(boardID, title, mode string, flags []string) (*Scene, error) {
+	payload := map[string]interface{}{
+		"title": title,
+		"mode":  mode,
+		"flags": flags,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/scenes", boardID), payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("create scene failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Scene *Scene `json:"scene"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode scene response: %w", err)
+	}
+
+	if result.Scene == nil {
+		return nil, fmt.Errorf("no scene in response")
+	}
+
+	return result.Scene, nil
+}
+
+// ReorderScenes sets the board's scene order to sceneIDs (facilitator only)
+func (c *APIClient) ReorderScenes(boardID string, sceneIDs []string) error {
+	orders := make([]map[string]interface{}, len(sceneIDs))
+	for i, id := range sceneIDs {
+		orders[i] = map[string]interface{}{"id": id, "seq": i + 1}
+	}
+	payload := map[string]interface{}{
+		"sceneOrders": orders,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/scenes/reorder", boardID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("reorder scenes failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CreateColumn adds a column at the end of the board (facilitator only)
+func (c *APIClient) CreateColumn(boardID, title string) (*Column, error) {
+	payload := map[string]string{
+		"title": title,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/columns", boardID), payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("create column failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Column *Column `json:"column"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode column response: %w", err)
+	}
+
+	if result.Column == nil {
+		return nil, fmt.Errorf("no column in response")
+	}
+
+	return result.Column, nil
+}
+
+// RenameColumn changes a column's title (facilitator only)
+func (c *APIClient) RenameColumn(boardID, columnID, title string) error {
+	payload := map[string]string{
+		"title": title,
+	}
+
+	resp, err := c.patch(fmt.Sprintf("/api/boards/%s/columns/%s", boardID, columnID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("rename column failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// DeleteColumn deletes a column and the cards in it (facilitator only)
+func (c *APIClient) DeleteColumn(boardID, columnID string) error {
+	resp, err := c.delete(fmt.Sprintf("/api/boards/%s/columns/%s", boardID, columnID))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete column failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// ReorderColumns sets the board's column order to columnIDs (facilitator only)
+func (c *APIClient) ReorderColumns(boardID string, columnIDs []string) error {
+	orders := make([]map[string]interface{}, len(columnIDs))
+	for i, id := range columnIDs {
+		orders[i] = map[string]interface{}{"id": id, "seq": i + 1}
+	}
+	payload := map[string]interface{}{
+		"columnOrders": orders,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/boards/%s/columns/reorder", boardID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("reorder columns failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// ApplyHealthPreset replaces a survey scene's responses and appends a preset's questions (facilitator only)
+func (c *APIClient) ApplyHealthPreset(sceneID, presetID string) ([]HealthQuestion, error) {
+	payload := map[string]string{
+		"presetId": presetID,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/scenes/%s/apply-health-preset", sceneID), payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("apply health preset failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Questions []HealthQuestion `json:"questions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode health questions: %w", err)
+	}
+
+	return result.Questions, nil
+}
+
+// GetHealthQuestions lists a survey scene's questions
+func (c *APIClient) GetHealthQuestions(sceneID string) ([]HealthQuestion, error) {
+	resp, err := c.get(fmt.Sprintf("/api/scenes/%s/health-questions", sceneID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get health questions failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Questions []HealthQuestion `json:"questions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode health questions: %w", err)
+	}
+
+	return result.Questions, nil
+}
+
+// SubmitHealthResponse answers one health question, replacing any earlier answer
+func (c *APIClient) SubmitHealthResponse(questionID string, rating int) error {
+	payload := map[string]interface{}{
+		"questionId": questionID,
+		"rating":     rating,
+	}
+
+	resp, err := c.post("/api/health-responses", payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("submit health response failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetHealthResults returns per-question response counts and averages for a survey scene
+func (c *APIClient) GetHealthResults(sceneID string) ([]HealthResult, error) {
+	resp, err := c.get(fmt.Sprintf("/api/scenes/%s/health-results", sceneID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get health results failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Results []HealthResult `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode health results: %w", err)
+	}
+
+	return result.Results, nil
+}
+
+// GetCompletionStatus reports whether the caller has answered every question in a survey scene
+func (c *APIClient) GetCompletionStatus(sceneID string) (*CompletionStatus, error) {
+	resp, err := c.get(fmt.Sprintf("/api/scenes/%s/completion-status", sceneID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get completion status failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	status := &CompletionStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, fmt.Errorf("decode completion status: %w", err)
+	}
+
+	return status, nil
+}
+
+// SetQuadrantPosition places the caller's own marker for a card in a quadrant scene's input phase
+func (c *APIClient) SetQuadrantPosition(cardID, sceneID string, x, y int) error {
+	payload := map[string]interface{}{
+		"scene_id": sceneID,
+		"x_value":  x,
+		"y_value":  y,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/quadrant-positions/%s", cardID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("set quadrant position failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// StartQuadrantInput opens a quadrant scene's input phase (facilitator only)
+func (c *APIClient) StartQuadrantInput(sceneID string) error {
+	resp, err := c.post(fmt.Sprintf("/api/scenes/%s/quadrant/start-input", sceneID), map[string]string{})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("start quadrant input failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CalculateConsensus closes the input phase and returns the IDs of the cards
+// that received a consensus position (facilitator only)
+func (c *APIClient) CalculateConsensus(sceneID string) ([]string, error) {
+	resp, err := c.post(fmt.Sprintf("/api/scenes/%s/quadrant/calculate-consensus", sceneID), map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("calculate consensus failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		CardPositions []struct {
+			CardID string `json:"card_id"`
+		} `json:"card_positions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode consensus: %w", err)
+	}
+
+	cardIDs := make([]string, 0, len(result.CardPositions))
+	for _, position := range result.CardPositions {
+		cardIDs = append(cardIDs, position.CardID)
+	}
+	return cardIDs, nil
+}
+
+// ResetQuadrantPositions deletes every participant's markers in a quadrant scene
+func (c *APIClient) ResetQuadrantPositions(sceneID string) error {
+	resp, err := c.post(fmt.Sprintf("/api/scenes/%s/quadrant/reset-all-positions", sceneID), map[string]string{})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("reset quadrant positions failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// AdjustQuadrantPosition moves a card's consensus position in the results phase (facilitator only)
+func (c *APIClient) AdjustQuadrantPosition(cardID, sceneID string, x, y int) error {
+	payload := map[string]interface{}{
+		"scene_id": sceneID,
+		"x_value":  x,
+		"y_value":  y,
+	}
+
+	resp, err := c.put(fmt.Sprintf("/api/cards/%s/quadrant-position", cardID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("adjust quadrant position failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// SetFacilitatorPosition drags a card's facilitator marker in the results phase (facilitator only)
+func (c *APIClient) SetFacilitatorPosition(sceneID, cardID string, x, y int) error {
+	payload := map[string]interface{}{
+		"card_id":       cardID,
+		"facilitator_x": x,
+		"facilitator_y": y,
+	}
+
+	resp, err := c.patch(fmt.Sprintf("/api/scenes/%s/quadrant/facilitator-position", sceneID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("set facilitator position failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CreateScorecard adds a scorecard to a series (facilitator only)
+func (c *APIClient) CreateScorecard(seriesID, name, description string) (*Scorecard, error) {
+	payload := map[string]string{
+		"name":        name,
+		"description": description,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/series/%s/scorecards", seriesID), payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("create scorecard failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Scorecard *Scorecard `json:"scorecard"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode scorecard response: %w", err)
+	}
+
+	if result.Scorecard == nil {
+		return nil, fmt.Errorf("no scorecard in response")
+	}
+
+	return result.Scorecard, nil
+}
+
+// CreateScorecardDatasource adds a paste datasource with the given rules to a scorecard (facilitator only)
+func (c *APIClient) CreateScorecardDatasource(scorecardID, name string, rules []map[string]interface{}) (*ScorecardDatasource, error) {
+	payload := map[string]interface{}{
+		"name":        name,
+		"source_type": "paste",
+		"rules":       rules,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/scorecards/%s/datasources", scorecardID), payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("create datasource failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Datasource *ScorecardDatasource `json:"datasource"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode datasource response: %w", err)
+	}
+
+	if result.Datasource == nil {
+		return nil, fmt.Errorf("no datasource in response")
+	}
+
+	return result.Datasource, nil
+}
+
+// AttachScorecard attaches a scorecard to a scene (facilitator only)
+func (c *APIClient) AttachScorecard(sceneID, scorecardID string) (*SceneScorecard, error) {
+	payload := map[string]string{
+		"scorecard_id": scorecardID,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/scenes/%s/scorecards", sceneID), payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("attach scorecard failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		SceneScorecard *SceneScorecard `json:"sceneScorecard"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode scene scorecard response: %w", err)
+	}
+
+	if result.SceneScorecard == nil {
+		return nil, fmt.Errorf("no scene scorecard in response")
+	}
+
+	return result.SceneScorecard, nil
+}
+
+// CollectScorecardData replaces an attached scorecard's data and reprocesses its rules,
+// returning the number of results (facilitator only). data maps datasource ID to its rows.
+func (c *APIClient) CollectScorecardData(sceneScorecardID string, data map[string]interface{}) (int, error) {
+	payload := map[string]interface{}{
+		"datasource_data": data,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/scene-scorecards/%s/collect-data", sceneScorecardID), payload)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("collect scorecard data failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		ResultCount int `json:"resultCount"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decode collect response: %w", err)
+	}
+
+	return result.ResultCount, nil
+}
+
+// GetScorecardResults lists an attached scorecard's processed results
+func (c *APIClient) GetScorecardResults(sceneScorecardID string) ([]ScorecardResult, error) {
+	resp, err := c.get(fmt.Sprintf("/api/scene-scorecards/%s/results", sceneScorecardID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get scorecard results failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Results []ScorecardResult `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode scorecard results: %w", err)
+	}
+
+	return result.Results, nil
+}
+
+// FlagScorecardResult turns a scorecard result into a card in columnID for discussion
+func (c *APIClient) FlagScorecardResult(resultID, columnID string) error {
+	payload := map[string]string{
+		"column_id": columnID,
+	}
+
+	resp, err := c.post(fmt.Sprintf("/api/scene-scorecard-results/%s/flag", resultID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("flag scorecard result failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Helper methods for HTTP operations
+
+func (c *APIClient) get(path string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
+}
+
+func (c *APIClient) post(path string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.httpClient.Do(req)
+}
+
+func (c *APIClient) put(path string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.httpClient.Do(req)
+}
+
+func (c *APIClient) patch(path string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.httpClient.Do(req)
+}
+
+func (c *APIClient) delete(path string) (*http.Response, error) {
+	req, err := http.NewRequest("DELETE", c.baseURL+path, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 	return c.httpClient.Do(req)
 }
 
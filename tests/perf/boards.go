@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BoardTarget is one board users are spread across
+type BoardTarget struct {
+	ID        string
+	SeriesID  string
+	ColumnIDs []string
+	Size      int         // Users planned for the board; 0 takes an equal share
+	Run       *RunContext // Shared trackers, plus this board's own
+}
+
+// setupBoard creates a board in seriesID from the configured template,
+// activates it and returns it with its column IDs
+func setupBoard(adminAPI *APIClient, config *Config, name, seriesID string) (*BoardState, []string, error) {
+	board, err := adminAPI.CreateBoard(name, seriesID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create board: %w", err)
+	}
+	boardID := board.ID
+
+	if err := adminAPI.SetupBoardTemplate(boardID, config.Template); err != nil {
+		return nil, nil, fmt.Errorf("failed to setup board template: %w", err)
+	}
+
+	if err := adminAPI.UpdateBoard(boardID, map[string]interface{}{"status": "active"}); err != nil {
+		PrintWarning("Setup", "Failed to activate board, continuing anyway")
+	}
+
+	// Get board state with columns
+	board, err = adminAPI.GetBoard(boardID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get board state: %w", err)
+	}
+
+	var columnIDs []string
+	for _, col := range board.Columns {
+		columnIDs = append(columnIDs, col.ID)
+	}
+
+	if len(columnIDs) == 0 {
+		return nil, nil, fmt.Errorf("no columns found in board")
+	}
+	return board, columnIDs, nil
+}
+
+// enableSceneFlags forces the configured flags onto the board's current
+// scene, verifies they took, and returns the board as re-fetched along with
+// that scene, or nil if the board has none
+func enableSceneFlags(adminAPI *APIClient, config *Config, board *BoardState) (*BoardState, *Scene, error) {
+	if board.CurrentSceneID == "" {
+		PrintWarning("Setup", "No current scene ID - permissions may be restricted")
+		return board, nil, nil
+	}
+
+	var currentScene *Scene
+	for _, scene := range board.Scenes {
+		if scene.ID == board.CurrentSceneID {
+			currentScene = &scene
+			break
+		}
+	}
+	if currentScene == nil {
+		PrintWarning("Setup", "No current scene found - permissions may be restricted")
+		return board, nil, nil
+	}
+
+	// Scene permissions are stored as flags in an array
+	err := adminAPI.UpdateScene(board.ID, currentScene.ID, map[string]interface{}{
+		"flags": config.SceneFlags,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update scene permissions: %w", err)
+	}
+
+	// Verify permissions were set by re-fetching board
+	board, err = adminAPI.GetBoard(board.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify board state: %w", err)
+	}
+
+	if config.Verbose {
+		fmt.Printf("DEBUG: Re-fetched board %s, currentSceneID: %s\n", board.ID, board.CurrentSceneID)
+		fmt.Printf("DEBUG: Number of scenes: %d\n", len(board.Scenes))
+		for i, scene := range board.Scenes {
+			fmt.Printf("DEBUG: Scene %d: ID=%s, Title=%s, Flags=%v\n",
+				i, scene.ID, scene.Title, scene.Flags)
+		}
+	}
+
+	// Verify the scene has the required flags
+	for _, scene := range board.Scenes {
+		if scene.ID == board.CurrentSceneID {
+			var missing []string
+			for _, sceneFlag := range config.SceneFlags {
+				if !scene.HasFlag(sceneFlag) {
+					missing = append(missing, sceneFlag)
+				}
+			}
+
+			if len(missing) > 0 {
+				return nil, nil, fmt.Errorf("scene permissions not properly set: missing %v (flags=%v)",
+					missing, scene.Flags)
+			}
+			break
+		}
+	}
+	return board, currentScene, nil
+}
+
+// seriesCount returns how many series a run's boards are dealt across
+func seriesCount(config *Config) int {
+	if config.Series < 1 || config.Series > config.Boards {
+		return config.Boards
+	}
+	return config.Series
+}
+
+// boardSize returns the users planned for board index (from 0), or 0 when
+// users are shared out evenly
+func boardSize(config *Config, index int) int {
+	if index < len(config.BoardSizes) {
+		return config.BoardSizes[index]
+	}
+	return 0
+}
+
+// setupNeighbourBoards creates the boards after the first for a multi-board
+// run. Board i goes into series (i-1) mod the series count, the first series
+// being seriesID, so with the default of one series per board no user
+// belongs to two boards. Boards are set up fleetSetupWorkers at a time. The
+// caller gives each its Run.
+func setupNeighbourBoards(adminAPI *APIClient, config *Config, timestamp, seriesID string) ([]*BoardTarget, error) {
+	seriesIDs := make([]string, seriesCount(config))
+	seriesIDs[0] = seriesID
+	err := runSetupWorkers(len(seriesIDs)-1, func(i int) error {
+		series, err := adminAPI.CreateSeries(fmt.Sprintf("Load Test Series %s #%d", timestamp, i+2), "Series for load testing")
+		if err != nil {
+			return fmt.Errorf("failed to create series %d: %w", i+2, err)
+		}
+		seriesIDs[i+1] = series.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]*BoardTarget, config.Boards-1)
+	err = runSetupWorkers(len(boards), func(i int) error {
+		number := i + 2
+		seriesID := seriesIDs[(number-1)%len(seriesIDs)]
+		board, columnIDs, err := setupBoard(adminAPI, config, fmt.Sprintf("Load Test Board %s #%d", timestamp, number), seriesID)
+		if err != nil {
+			return fmt.Errorf("board %d: %w", number, err)
+		}
+		if _, _, err := enableSceneFlags(adminAPI, config, board); err != nil {
+			return fmt.Errorf("board %d: %w", number, err)
+		}
+
+		boards[i] = &BoardTarget{ID: board.ID, SeriesID: seriesID, ColumnIDs: columnIDs, Size: boardSize(config, i+1)}
+		if config.Verbose {
+			PrintSetupProgress("✓", fmt.Sprintf("Board %d/%d ready (%d columns)", number, config.Boards, len(columnIDs)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(boards) > 0 {
+		PrintSetupProgress("✓", fmt.Sprintf("%d boards ready across %d series", config.Boards, len(seriesIDs)))
+	}
+	return boards, nil
+}
+
+// runSetupWorkers calls work for 0 to n-1, fleetSetupWorkers at a time, and
+// returns the first error once every call has finished
+func runSetupWorkers(n int, work func(i int) error) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	next := make(chan int)
+	for w := 0; w < min(fleetSetupWorkers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if err := work(i); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return firstErr
+}
+
+// neighbour returns the context for users of another board than the one
+// r drives: the run-wide trackers are shared, while the workload, presence
+// model and convergence checks stay with r's board
+func (r *RunContext) neighbour() *RunContext {
+	return &RunContext{
+		Config:     r.Config,
+		Correlator: r.Correlator,
+		Timings:    r.Timings,
+		Notes:      r.Notes,
+		Clones:     r.Clones,
+		Schema:     r.Schema,
+		Isolation:  r.Isolation,
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Limits on what the churn driver adds, so long runs keep a usable board
+const (
+	churnMaxColumns = 3 // Columns the driver keeps alive at once
+	churnMaxScenes  = 5 // Scenes the driver creates over the whole run
+)
+
+// Board admin churn operations
+const (
+	churnAddColumn      = "add_column"
+	churnRenameColumn   = "rename_column"
+	churnReorderColumns = "reorder_columns"
+	churnDeleteColumn   = "delete_column"
+	churnCreateScene    = "create_scene"
+	churnReorderScenes  = "reorder_scenes"
+)
+
+// churnOperations lists the operations in report order
+var churnOperations = []string{
+	churnAddColumn,
+	churnRenameColumn,
+	churnReorderColumns,
+	churnDeleteColumn,
+	churnCreateScene,
+	churnReorderScenes,
+}
+
+// churnOperationStats accumulates one operation's outcomes
+type churnOperationStats struct {
+	count     int
+	failures  int
+	latencies []time.Duration
+}
+
+// ChurnDriver reconfigures the board while participants use it, the way a
+// board admin tidies up mid-meeting: it adds, renames, reorders and deletes
+// columns and creates and reorders scenes. It only deletes columns it added
+// itself, and remembers them so participant actions that failed against one
+// can be counted.
+type ChurnDriver struct {
+	api           *APIClient
+	boardID       string
+	interval      time.Duration
+	correlator    *EventCorrelator
+	columns       []string        // Columns the driver added that still exist
+	deleted       map[string]bool // Columns the driver deleted
+	scenes        int
+	operations    map[string]*churnOperationStats
+	staleFailures map[string]int // action -> failures against a deleted column
+	mu            sync.Mutex
+}
+
+// NewChurnDriver creates a driver running one operation on the board every interval
+func NewChurnDriver(api *APIClient, boardID string, interval time.Duration, correlator *EventCorrelator) *ChurnDriver {
+	operations := make(map[string]*churnOperationStats)
+	for _, name := range churnOperations {
+		operations[name] = &churnOperationStats{}
+	}
+	return &ChurnDriver{
+		api:           api,
+		boardID:       boardID,
+		interval:      interval,
+		correlator:    correlator,
+		deleted:       make(map[string]bool),
+		operations:    operations,
+		staleFailures: make(map[string]int),
+	}
+}
+
+// RecordColumnFailure counts a failed participant action if the column it
+// targeted had been deleted by the driver
+func (c *ChurnDriver) RecordColumnFailure(action, columnID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.deleted[columnID] {
+		c.staleFailures[action]++
+	}
+}
+
+// Run performs a random operation every interval until stopChan closes
+func (c *ChurnDriver) Run(stopChan <-chan bool) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			name := churnOperations[rand.Intn(len(churnOperations))]
+			if err := c.perform(name); err != nil {
+				PrintWarning("Churn", fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+	}
+}
+
+// perform runs one operation, switching to its counterpart when the driver's
+// limits rule it out
+func (c *ChurnDriver) perform(name string) error {
+	c.mu.Lock()
+	switch {
+	case name == churnAddColumn && len(c.columns) >= churnMaxColumns:
+		name = churnDeleteColumn
+	case name == churnDeleteColumn && len(c.columns) == 0:
+		name = churnAddColumn
+	case name == churnCreateScene && c.scenes >= churnMaxScenes:
+		name = churnReorderScenes
+	}
+	c.mu.Unlock()
+
+	started := time.Now()
+	var err error
+	switch name {
+	case churnAddColumn:
+		err = c.addColumn()
+	case churnRenameColumn:
+		err = c.renameColumn()
+	case churnReorderColumns:
+		err = c.reorderColumns()
+	case churnDeleteColumn:
+		err = c.deleteColumn()
+	case churnCreateScene:
+		err = c.createScene()
+	case churnReorderScenes:
+		err = c.reorderScenes()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.operations[name]
+	stats.count++
+	if err != nil {
+		stats.failures++
+	} else {
+		stats.latencies = append(stats.latencies, time.Since(started))
+	}
+	return err
+}
+
+func (c *ChurnDriver) addColumn() error {
+	column, err := c.api.CreateColumn(c.boardID, fmt.Sprintf("Churn column %s", time.Now().Format("15:04:05")))
+	if err != nil {
+		return err
+	}
+	c.correlator.RecordSentEvent("columns_updated", c.boardID, 0)
+	c.mu.Lock()
+	c.columns = append(c.columns, column.ID)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *ChurnDriver) renameColumn() error {
+	board, err := c.api.GetBoard(c.boardID)
+	if err != nil {
+		return err
+	}
+	if len(board.Columns) == 0 {
+		return nil
+	}
+	column := board.Columns[rand.Intn(len(board.Columns))]
+	if err := c.api.RenameColumn(c.boardID, column.ID, fmt.Sprintf("Renamed at %s", time.Now().Format("15:04:05"))); err != nil {
+		return err
+	}
+	c.correlator.RecordSentEvent("columns_updated", c.boardID, 0)
+	return nil
+}
+
+func (c *ChurnDriver) reorderColumns() error {
+	board, err := c.api.GetBoard(c.boardID)
+	if err != nil {
+		return err
+	}
+	columnIDs := make([]string, len(board.Columns))
+	for i, column := range board.Columns {
+		columnIDs[i] = column.ID
+	}
+	rand.Shuffle(len(columnIDs), func(i, j int) { columnIDs[i], columnIDs[j] = columnIDs[j], columnIDs[i] })
+	if err := c.api.ReorderColumns(c.boardID, columnIDs); err != nil {
+		return err
+	}
+	c.correlator.RecordSentEvent("columns_updated", c.boardID, 0)
+	return nil
+}
+
+// deleteColumn deletes one of the driver's columns, along with any cards
+// participants put in it
+func (c *ChurnDriver) deleteColumn() error {
+	c.mu.Lock()
+	if len(c.columns) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	i := rand.Intn(len(c.columns))
+	columnID := c.columns[i]
+	c.columns = append(c.columns[:i], c.columns[i+1:]...)
+	// Count failures from here on: participants can race the delete itself
+	c.deleted[columnID] = true
+	c.mu.Unlock()
+
+	if err := c.api.DeleteColumn(c.boardID, columnID); err != nil {
+		// The column is still live, so keep churning it and stop excusing failures on it
+		c.mu.Lock()
+		c.columns = append(c.columns, columnID)
+		delete(c.deleted, columnID)
+		c.mu.Unlock()
+		return err
+	}
+	c.correlator.RecordSentEvent("columns_updated", c.boardID, 0)
+	return nil
+}
+
+func (c *ChurnDriver) createScene() error {
+	c.mu.Lock()
+	c.scenes++
+	number := c.scenes
+	c.mu.Unlock()
+
+	scene, err := c.api.CreateScene(c.boardID, fmt.Sprintf("Churn scene %d", number), "columns", nil)
+	if err != nil {
+		return err
+	}
+	c.correlator.RecordSentEvent("scene_created", scene.ID, 0)
+	return nil
+}
+
+// reorderScenes shuffles the scene order, which the server does not broadcast
+func (c *ChurnDriver) reorderScenes() error {
+	board, err := c.api.GetBoard(c.boardID)
+	if err != nil {
+		return err
+	}
+	sceneIDs := make([]string, len(board.Scenes))
+	for i, scene := range board.Scenes {
+		sceneIDs[i] = scene.ID
+	}
+	rand.Shuffle(len(sceneIDs), func(i, j int) { sceneIDs[i], sceneIDs[j] = sceneIDs[j], sceneIDs[i] })
+	return c.api.ReorderScenes(c.boardID, sceneIDs)
+}
+
+// Report returns the outcome of each operation and the participant actions
+// that failed against a deleted column
+func (c *ChurnDriver) Report() *ChurnReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := &ChurnReport{
+		Interval:            c.interval,
+		StaleColumnFailures: make(map[string]int),
+	}
+	for _, name := range churnOperations {
+		stats := c.operations[name]
+		report.Operations = append(report.Operations, ChurnOperation{
+			Name:     name,
+			Count:    stats.count,
+			Failures: stats.failures,
+			Latency:  computeLatencyStats(stats.latencies),
+		})
+	}
+	for action, failures := range c.staleFailures {
+		report.StaleColumnFailures[action] = failures
+	}
+	return report
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cloneMaxMismatches caps the differences kept for the report
+const cloneMaxMismatches = 10
+
+// cloneAttempt is one clone of the board under test
+type cloneAttempt struct {
+	started    time.Time
+	finished   time.Time
+	cards      int      // Cards on the source when it was snapshotted
+	mismatches []string // Differences between the clone and the snapshot
+	err        error
+}
+
+// CloneTracker collects the clones facilitators take of the board under
+// test. Each clone's request is timed, and the events sent on the source
+// board while it ran are later compared with those sent in an equally long
+// window just before, to show whether the clone held up delivery.
+type CloneTracker struct {
+	correlator *EventCorrelator
+	attempts   []cloneAttempt
+	mu         sync.Mutex
+}
+
+// NewCloneTracker creates an empty tracker reading delivery from correlator
+func NewCloneTracker(correlator *EventCorrelator) *CloneTracker {
+	return &CloneTracker{correlator: correlator}
+}
+
+// Record stores one clone, which ran from started to finished
+func (c *CloneTracker) Record(started, finished time.Time, cards int, mismatches []string, err error) {
+	// Keep both windows' delivery before the correlator retires their events
+	if err == nil {
+		took := finished.Sub(started)
+		c.correlator.WatchDelivery(started.Add(-took), started)
+		c.correlator.WatchDelivery(started, finished)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts = append(c.attempts, cloneAttempt{
+		started:    started,
+		finished:   finished,
+		cards:      cards,
+		mismatches: mismatches,
+		err:        err,
+	})
+}
+
+// Report returns clone timings, mismatches and the delivery of source board
+// events around each clone, or nil if nobody cloned the board
+func (c *CloneTracker) Report() *CloneReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.attempts) == 0 {
+		return nil
+	}
+
+	report := &CloneReport{
+		Attempts: len(c.attempts),
+		Before:   &DeliveryStats{},
+		During:   &DeliveryStats{},
+	}
+	var latencies []time.Duration
+	for _, attempt := range c.attempts {
+		if attempt.err != nil {
+			report.Failures++
+			if len(report.Errors) < cloneMaxMismatches {
+				report.Errors = append(report.Errors, attempt.err.Error())
+			}
+			continue
+		}
+		took := attempt.finished.Sub(attempt.started)
+		latencies = append(latencies, took)
+		report.SourceCards += attempt.cards
+		if len(attempt.mismatches) > 0 {
+			report.Mismatched++
+			for _, mismatch := range attempt.mismatches {
+				if len(report.Mismatches) < cloneMaxMismatches {
+					report.Mismatches = append(report.Mismatches, mismatch)
+				}
+			}
+		}
+		report.Before.Add(c.correlator.DeliveryBetween(attempt.started.Add(-took), attempt.started))
+		report.During.Add(c.correlator.DeliveryBetween(attempt.started, attempt.finished))
+	}
+	report.Latency = computeLatencyStats(latencies)
+	return report
+}
+
+// compareClone lists how clone differs from the source snapshot. A clone
+// copies columns, scenes and their flags but no cards, so the clone is
+// expected to start empty whatever the source held.
+func compareClone(source, clone *BoardState) []string {
+	var mismatches []string
+
+	if len(clone.AllColumns) != len(source.AllColumns) {
+		mismatches = append(mismatches, fmt.Sprintf("columns: %d in source, %d in clone",
+			len(source.AllColumns), len(clone.AllColumns)))
+	} else {
+		for i, column := range source.AllColumns {
+			if clone.AllColumns[i].Name != column.Name {
+				mismatches = append(mismatches, fmt.Sprintf("column %d: %q in source, %q in clone",
+					i+1, column.Name, clone.AllColumns[i].Name))
+			}
+		}
+	}
+
+	if len(clone.Scenes) != len(source.Scenes) {
+		mismatches = append(mismatches, fmt.Sprintf("scenes: %d in source, %d in clone",
+			len(source.Scenes), len(clone.Scenes)))
+	} else {
+		for i, scene := range source.Scenes {
+			cloned := clone.Scenes[i]
+			if cloned.Title != scene.Title || cloned.Mode != scene.Mode {
+				mismatches = append(mismatches, fmt.Sprintf("scene %d: %q (%s) in source, %q (%s) in clone",
+					i+1, scene.Title, scene.Mode, cloned.Title, cloned.Mode))
+			} else if sourceFlags, clonedFlags := sortedFlags(scene), sortedFlags(cloned); sourceFlags != clonedFlags {
+				mismatches = append(mismatches, fmt.Sprintf("scene %q flags: [%s] in source, [%s] in clone",
+					scene.Title, sourceFlags, clonedFlags))
+			}
+		}
+	}
+
+	if len(clone.Cards) > 0 {
+		mismatches = append(mismatches, fmt.Sprintf("cards: clone has %d, expected none", len(clone.Cards)))
+	}
+
+	return mismatches
+}
+
+// sortedFlags joins a scene's flags in a stable order
+func sortedFlags(scene Scene) string {
+	flags := append([]string{}, scene.Flags...)
+	sort.Strings(flags)
+	return strings.Join(flags, ", ")
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// convergenceSettle is how long a card must stay unchanged on the server
+// before every client is expected to agree on it
+const convergenceSettle = 3 * time.Second
+
+// convergenceMaxExamples caps the differences kept for each checkpoint
+const convergenceMaxExamples = 10
+
+// boardSnapshot is the server's board as a check compares it
+type boardSnapshot struct {
+	cards   map[string]replicaCard
+	votes   map[string]int // nil when the current scene hides votes
+	columns []string
+}
+
+// ConvergenceChecker diffs every connected client's BoardReplica against
+// the board the server returns, at checkpoints during the run and once
+// after the grace period. Each check reads the board twice, convergenceSettle
+// apart, and only holds clients to the cards and columns that did not change
+// in between; anything a client heard about after the second read is left
+// out too.
+type ConvergenceChecker struct {
+	api         *APIClient
+	boardID     string
+	interval    time.Duration
+	pool        *UserPool
+	started     time.Time
+	checkpoints []ConvergenceCheckpoint
+	mu          sync.Mutex
+}
+
+// NewConvergenceChecker creates a checker comparing the pool's replicas
+// with the board every interval; zero checks only at the end of the run
+func NewConvergenceChecker(api *APIClient, boardID string, interval time.Duration, pool *UserPool) *ConvergenceChecker {
+	return &ConvergenceChecker{
+		api:      api,
+		boardID:  boardID,
+		interval: interval,
+		pool:     pool,
+		started:  time.Now(),
+	}
+}
+
+// Run checks every interval until stopChan closes
+func (c *ConvergenceChecker) Run(stopChan <-chan bool) {
+	if c.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			c.record(c.check(false))
+		}
+	}
+}
+
+// Final runs the end-of-run check, once no more actions are being sent
+func (c *ConvergenceChecker) Final() {
+	c.record(c.check(true))
+}
+
+func (c *ConvergenceChecker) record(checkpoint ConvergenceCheckpoint) {
+	if checkpoint.Error != "" {
+		PrintWarning("Convergence", checkpoint.Error)
+	} else if checkpoint.Divergent > 0 {
+		PrintWarning("Convergence", fmt.Sprintf("%d of %d clients differ from the board", checkpoint.Divergent, checkpoint.Clients))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkpoints = append(c.checkpoints, checkpoint)
+}
+
+// check compares every connected client with the board
+func (c *ConvergenceChecker) check(final bool) ConvergenceCheckpoint {
+	checkpoint := ConvergenceCheckpoint{At: time.Since(c.started), Final: final}
+
+	before, err := c.snapshot()
+	if err != nil {
+		checkpoint.Error = err.Error()
+		return checkpoint
+	}
+	time.Sleep(convergenceSettle)
+	taken := time.Now()
+	after, err := c.snapshot()
+	if err != nil {
+		checkpoint.Error = err.Error()
+		return checkpoint
+	}
+
+	// Cards that changed between the reads are still settling
+	stable := make(map[string]bool, len(after.cards))
+	for cardID, card := range after.cards {
+		if previous, ok := before.cards[cardID]; ok && previous == card && before.votes[cardID] == after.votes[cardID] {
+			stable[cardID] = true
+		}
+	}
+	checkpoint.Cards = len(stable)
+	checkpoint.Settling = len(after.cards) - len(stable)
+	compareVotes := before.votes != nil && after.votes != nil
+	compareColumns := slices.Equal(before.columns, after.columns)
+
+	for _, user := range c.pool.Users() {
+		replica := user.Replica()
+		if replica == nil {
+			continue
+		}
+		checkpoint.Clients++
+		differences := c.compare(&checkpoint, replica.state(), before, after, stable, taken, compareVotes, compareColumns)
+		if len(differences) == 0 {
+			continue
+		}
+		checkpoint.Divergent++
+		for _, difference := range differences {
+			if len(checkpoint.Examples) < convergenceMaxExamples {
+				checkpoint.Examples = append(checkpoint.Examples, fmt.Sprintf("user %d: %s", user.GetID(), difference))
+			}
+		}
+	}
+	return checkpoint
+}
+
+// compare lists how one client's replica differs from the board, counting
+// each difference on the checkpoint
+func (c *ConvergenceChecker) compare(checkpoint *ConvergenceCheckpoint, replica replicaState, before, after *boardSnapshot,
+	stable map[string]bool, taken time.Time, compareVotes, compareColumns bool) []string {
+	var differences []string
+	heardSince := func(cardID string) bool {
+		return replica.touched[cardID].After(taken)
+	}
+
+	for cardID := range stable {
+		if heardSince(cardID) {
+			continue
+		}
+		want := after.cards[cardID]
+		got, ok := replica.cards[cardID]
+		switch {
+		case !ok:
+			checkpoint.Missing++
+			differences = append(differences, fmt.Sprintf("card %s missing", cardID))
+			continue
+		case got.columnID != want.columnID:
+			checkpoint.WrongColumn++
+			differences = append(differences, fmt.Sprintf("card %s in column %s, board has %s", cardID, got.columnID, want.columnID))
+		case got.groupID != want.groupID:
+			checkpoint.WrongGroup++
+			differences = append(differences, fmt.Sprintf("card %s in group %q, board has %q", cardID, got.groupID, want.groupID))
+		case got.content != want.content:
+			checkpoint.WrongContent++
+			differences = append(differences, fmt.Sprintf("card %s content differs", cardID))
+		}
+		if compareVotes && replica.votes != nil && replica.votes[cardID] != after.votes[cardID] {
+			checkpoint.WrongVotes++
+			differences = append(differences, fmt.Sprintf("card %s has %d votes, board has %d",
+				cardID, replica.votes[cardID], after.votes[cardID]))
+		}
+	}
+
+	// Cards deleted before the first read should be gone from every client
+	for cardID := range replica.cards {
+		_, inBefore := before.cards[cardID]
+		_, inAfter := after.cards[cardID]
+		if !inBefore && !inAfter && !heardSince(cardID) {
+			checkpoint.Extra++
+			differences = append(differences, fmt.Sprintf("card %s not on the board", cardID))
+		}
+	}
+
+	if compareColumns && !replica.columnsChanged.After(taken) && !slices.Equal(replica.columns, after.columns) {
+		checkpoint.WrongColumns++
+		differences = append(differences, fmt.Sprintf("%d columns, board has %d in a different order or set",
+			len(replica.columns), len(after.columns)))
+	}
+	return differences
+}
+
+// snapshot reads the board's cards, columns and, when shown, vote totals
+func (c *ConvergenceChecker) snapshot() (*boardSnapshot, error) {
+	board, err := c.api.GetBoard(c.boardID)
+	if err != nil {
+		return nil, fmt.Errorf("read board: %w", err)
+	}
+	votes, err := c.api.GetUserVotes(c.boardID)
+	if err != nil {
+		return nil, fmt.Errorf("read vote totals: %w", err)
+	}
+
+	snapshot := &boardSnapshot{
+		cards: make(map[string]replicaCard, len(board.Cards)),
+		votes: votes.AllVotesByCard,
+	}
+	for _, card := range board.Cards {
+		snapshot.cards[card.ID] = replicaCard{columnID: card.ColumnID, groupID: card.GroupID, content: card.Content}
+	}
+	for _, column := range board.AllColumns {
+		snapshot.columns = append(snapshot.columns, column.ID)
+	}
+	return snapshot, nil
+}
+
+// Report returns every checkpoint in the order they ran
+func (c *ConvergenceChecker) Report() *ConvergenceReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &ConvergenceReport{
+		Interval:    c.interval,
+		Settle:      convergenceSettle,
+		Checkpoints: append([]ConvergenceCheckpoint{}, c.checkpoints...),
+	}
+}
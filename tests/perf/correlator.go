@@ -2,168 +2,739 @@ package main
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// EventCorrelator tracks sent events and matches them with received events
+// Correlator sizing
+const (
+	correlatorShards     = 32
+	correlatorRetention  = 2 * time.Minute // How long a sent event collects receipts before its delivery is final
+	correlatorSweep      = time.Second     // How often each shard retires events past retention
+	correlatorMaxPending = 10000           // Receipts per shard waiting for their sent event
+	correlatorMaxMisses  = 1000            // Missed deliveries listed in the report
+	anomalyMaxExamples   = 10              // Examples kept of each kind of anomaly
+)
+
+// eventKey is what received events are matched to sent events on
+type eventKey struct {
+	eventType string
+	cardID    string
+}
+
+// Receipts are matched to the sent event of the same key whose tag they
+// carry, so two sends for one card each get their own deliveries. Tags are
+// values the sender chose or learned from the server's reply: a nonce
+// written into card content, the column a card was moved to, a card's
+// vote total after a vote, or the card a notes update was for.
+func contentTag(nonce string) string   { return "content:" + nonce }
+func columnTag(columnID string) string { return "column:" + columnID }
+func cardTag(cardID string) string     { return "card:" + cardID }
+
+// votesTag returns the tag for a card's vote total, or "" if it is unknown
+func votesTag(count *int) string {
+	if count == nil {
+		return ""
+	}
+	return fmt.Sprintf("votes:%d", *count)
+}
+
+// contentNoncePattern finds the nonce withNonce appends to card content
+var contentNoncePattern = regexp.MustCompile(` #(\d+\.\d+)$`)
+
+// withNonce appends nonce to card content
+func withNonce(content, nonce string) string {
+	return content + " #" + nonce
+}
+
+// contentNonce returns the nonce in card content, or "" if it has none
+func contentNonce(content string) string {
+	if match := contentNoncePattern.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// trackedEvent is a sent event along with its receipts
+type trackedEvent struct {
+	SentEvent
+	key       eventKey
+	persona   string          // Sender's persona
+	receivers []uint64        // Bit set of receiver IDs; nil once retired
+	latencies []time.Duration // One per expected receipt; nil once retired
+	received  int
+	slowest   time.Duration
+}
+
+// hasReceived reports whether receiverID has a receipt of the event
+func (e *trackedEvent) hasReceived(receiverID int) bool {
+	return ReceiverSet(e.receivers).Has(receiverID)
+}
+
+// missed calls fn for every expected user without a receipt of the event
+func (e *trackedEvent) missed(fn func(userID int)) {
+	for i, word := range e.Expected {
+		if i < len(e.receivers) {
+			word &^= e.receivers[i]
+		}
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			fn(i*64 + bit)
+			word &^= 1 << uint(bit)
+		}
+	}
+}
+
+// pendingReceipt is a receipt that arrived before its sent event was recorded
+type pendingReceipt struct {
+	receiverID int
+	at         time.Time
+	tags       []string
+}
+
+// matches reports whether the receipt may belong to event
+func (r pendingReceipt) matches(event *trackedEvent) bool {
+	return event.Tag == "" || len(r.tags) == 0 || slices.Contains(r.tags, event.Tag)
+}
+
+// match picks the sent event a receipt belongs to: the newest event of its
+// key whose tag the receipt carries and that receiverID has not received
+// yet, or else the newest such event that is untagged, or any event if the
+// receipt is untagged. A receipt every such event already has is matched to
+// the newest of them, which ignores it. A tagged receipt with no candidate
+// is left to wait for its send.
+func match(events []*trackedEvent, receiverID int, tags []string) *trackedEvent {
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		if event.Tag != "" && slices.Contains(tags, event.Tag) && !event.hasReceived(receiverID) {
+			return event
+		}
+	}
+	var repeat *trackedEvent
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		if event.Tag != "" && len(tags) > 0 {
+			continue
+		}
+		if !event.hasReceived(receiverID) {
+			return event
+		}
+		if repeat == nil {
+			repeat = event
+		}
+	}
+	return repeat
+}
+
+// deliveryWindow is a stretch of send times whose delivery is kept past retention
+type deliveryWindow struct {
+	from  time.Time
+	to    time.Time
+	stats *DeliveryStats
+}
+
+// correlatorShard holds the events for the cards that hash to it. Every
+// event type for one card lands in the same shard, so matching a receipt
+// or recording a send takes one shard's lock and a map lookup.
+type correlatorShard struct {
+	live         map[string]*trackedEvent // eventID -> event still within retention
+	order        []*trackedEvent          // Live events, oldest first
+	byKey        map[eventKey][]*trackedEvent
+	pending      map[eventKey][]pendingReceipt
+	pendingCount int
+	sentKeys     map[eventKey]bool        // Keys with at least one recorded send
+	deletedCards map[string]bool          // Cards whose card_deleted was sent
+	drivers      map[string]*trackedEvent // Events sent by a driver, kept for DeliveryFor after retirement
+	totals       *deliveryTotals
+	misses       []MissedDelivery
+	missedByUser map[int]int
+	unexpected   int
+	nextSweep    time.Time
+	mu           sync.Mutex
+}
+
+// EventCorrelator tracks sent events and matches them with received events.
+// Sends and receipts are indexed by event type and card ID and spread over
+// shards by card, so neither scans earlier events. Every sent event keeps
+// the set of users connected to the sender's board when it was sent, and
+// only their receipts count. Delivery is totalled as receipts arrive; each
+// event's own receipts are held only for correlatorRetention, after which a
+// receipt no longer counts and the users still missing it are recorded, so
+// memory follows the event rate rather than the length of the run.
 type EventCorrelator struct {
-	sentEvents        map[string]*SentEvent              // eventID -> SentEvent
-	receivedEvents    map[string]map[int]time.Time       // eventID -> receiverID -> receiveTime
-	pendingReceived   []ReceivedEvent                    // Events received before corresponding sent event
-	latencies         []time.Duration                    // all latencies for percentile calculation
-	connectedUsers    int                                // Current count of connected users
-	mu                sync.RWMutex
-	verbose           bool
+	shards     [correlatorShards]*correlatorShard
+	sent       atomic.Int64
+	received   atomic.Int64
+	connected  map[string]ReceiverSet // board -> users connected to it now
+	boards     map[int]string         // user -> board they joined; 0 is the drivers'
+	personas   map[int]string
+	phase      string   // Current meeting scene, if a workload sets one
+	phaseOrder []string // Phases in the order they started
+	stateMu    sync.RWMutex
+	windows    []*deliveryWindow
+	windowMu   sync.Mutex
+	anomalies  AnomalyReport
+	anomalyMu  sync.Mutex
+	verbose    bool
 }
 
 // NewEventCorrelator creates a new event correlator
 func NewEventCorrelator(verbose bool) *EventCorrelator {
-	return &EventCorrelator{
-		sentEvents:      make(map[string]*SentEvent),
-		receivedEvents:  make(map[string]map[int]time.Time),
-		pendingReceived: make([]ReceivedEvent, 0),
-		latencies:       make([]time.Duration, 0),
-		verbose:         verbose,
+	c := &EventCorrelator{
+		connected: make(map[string]ReceiverSet),
+		boards:    make(map[int]string),
+		personas:  make(map[int]string),
+		anomalies: AnomalyReport{DuplicatesByUser: make(map[int]int)},
+		verbose:   verbose,
 	}
+	for i := range c.shards {
+		c.shards[i] = &correlatorShard{
+			live:         make(map[string]*trackedEvent),
+			byKey:        make(map[eventKey][]*trackedEvent),
+			pending:      make(map[eventKey][]pendingReceipt),
+			sentKeys:     make(map[eventKey]bool),
+			deletedCards: make(map[string]bool),
+			drivers:      make(map[string]*trackedEvent),
+			totals:       newDeliveryTotals(),
+			missedByUser: make(map[int]int),
+		}
+	}
+	return c
+}
+
+// shardFor returns the shard holding cardID's events
+func (c *EventCorrelator) shardFor(cardID string) *correlatorShard {
+	h := fnv.New32a()
+	h.Write([]byte(cardID))
+	return c.shards[h.Sum32()%correlatorShards]
 }
 
 // RecordSentEvent records an event that was sent by a user
 func (c *EventCorrelator) RecordSentEvent(eventType, cardID string, senderID int) string {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	return c.RecordTaggedEvent(eventType, cardID, senderID, "")
+}
+
+// RecordTaggedEvent records a sent event whose receipts carry tag, so they
+// are told apart from those of other events for the same card
+func (c *EventCorrelator) RecordTaggedEvent(eventType, cardID string, senderID int, tag string) string {
+	return c.recordEvent(eventType, cardID, senderID, tag, func(connected ReceiverSet) ReceiverSet {
+		return connected
+	})
+}
+
+// RecordSenderEvent records an event the server sends to its sender alone
+func (c *EventCorrelator) RecordSenderEvent(eventType, cardID string, senderID int, tag string) string {
+	return c.recordEvent(eventType, cardID, senderID, tag, func(connected ReceiverSet) ReceiverSet {
+		if connected.Has(senderID) {
+			return ReceiverSet{}.With(senderID)
+		}
+		return ReceiverSet{}
+	})
+}
+
+// RecordOthersEvent records an event the server sends to everyone on the
+// sender's board except the sender
+func (c *EventCorrelator) RecordOthersEvent(eventType, cardID string, senderID int) string {
+	return c.recordEvent(eventType, cardID, senderID, "", func(connected ReceiverSet) ReceiverSet {
+		return connected.Without(senderID)
+	})
+}
+
+// recordEvent records a sent event expected by the users recipients picks
+// from those connected to the sender's board
+func (c *EventCorrelator) recordEvent(eventType, cardID string, senderID int, tag string, recipients func(connected ReceiverSet) ReceiverSet) string {
+	c.stateMu.RLock()
+	boardID := c.boards[senderID]
+	connected, phase, persona := c.connected[boardID], c.phase, c.personas[senderID]
+	c.stateMu.RUnlock()
+	expected := recipients(connected)
+
+	shard := c.shardFor(cardID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
 	// Create unique event ID
-	eventID := fmt.Sprintf("%s_%s_%d_%d", eventType, cardID, time.Now().UnixNano(), senderID)
-
-	c.sentEvents[eventID] = &SentEvent{
-		ID:             eventID,
-		Type:           eventType,
-		CardID:         cardID,
-		SenderID:       senderID,
-		Timestamp:      time.Now(),
-		ConnectedUsers: c.connectedUsers, // Snapshot of connected users at send time
-	}
-
-	// Initialize received tracking
-	c.receivedEvents[eventID] = make(map[int]time.Time)
-
-	// Check if any pending received events match this sent event
-	var remainingPending []ReceivedEvent
-	for _, pending := range c.pendingReceived {
-		if pending.Type == eventType && pending.CardID == cardID {
-			// This pending event matches (including self-events)!
-			c.receivedEvents[eventID][pending.ReceiverID] = pending.Timestamp
-			latency := pending.Timestamp.Sub(c.sentEvents[eventID].Timestamp)
-			c.latencies = append(c.latencies, latency)
-			if c.verbose {
-				fmt.Printf("📨 Matched pending event: %s for card %s by user %d (latency: %v)\n",
-					eventType, cardID, pending.ReceiverID, latency)
-			}
-		} else {
-			// Keep this pending event for future matching
-			remainingPending = append(remainingPending, pending)
+	now := time.Now()
+	eventID := fmt.Sprintf("%s_%s_%d_%d", eventType, cardID, now.UnixNano(), senderID)
+
+	// Once a card's deletion is sent, later events for it are not expected
+	// to reach anyone; a server that still accepted them lost a race
+	deleted := shard.deletedCards[cardID]
+	if eventType == "card_deleted" {
+		shard.deletedCards[cardID] = true
+	}
+
+	event := &trackedEvent{
+		SentEvent: SentEvent{
+			ID:             eventID,
+			Type:           eventType,
+			CardID:         cardID,
+			SenderID:       senderID,
+			Timestamp:      now,
+			BoardID:        boardID,
+			ConnectedUsers: expected.Len(),
+			Expected:       expected, // Snapshot of the board's connected users the event goes to
+			Tag:            tag,
+			Phase:          phase,
+			AfterDelete:    deleted,
+		},
+		key:     eventKey{eventType, cardID},
+		persona: persona,
+	}
+	shard.live[eventID] = event
+	shard.order = append(shard.order, event)
+	shard.byKey[event.key] = append(shard.byKey[event.key], event)
+	shard.sentKeys[event.key] = true
+	if senderID == 0 {
+		shard.drivers[eventID] = event
+	}
+	shard.totals.send(event)
+	c.sent.Add(1)
+
+	// Receipts that beat this send to the correlator (including self-events)
+	var waiting []pendingReceipt
+	for _, pending := range shard.pending[event.key] {
+		if !pending.matches(event) {
+			waiting = append(waiting, pending)
+			continue
+		}
+		if latency, ok := c.receive(shard, event, pending.receiverID, pending.at); ok && c.verbose {
+			fmt.Printf("📨 Matched pending event: %s for card %s by user %d (latency: %v)\n",
+				eventType, cardID, pending.receiverID, latency)
 		}
 	}
-	c.pendingReceived = remainingPending
+	shard.pendingCount -= len(shard.pending[event.key]) - len(waiting)
+	if len(waiting) > 0 {
+		shard.pending[event.key] = waiting
+	} else {
+		delete(shard.pending, event.key)
+	}
 
+	c.sweep(shard, now)
 	return eventID
 }
 
 // RecordReceivedEvent records an event received by a user via SSE
-func (c *EventCorrelator) RecordReceivedEvent(eventType, cardID string, receiverID int, receiveTime time.Time) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	// Find matching sent event (most recent for this type/cardID combination)
-	var matchingEventID string
-	var latestTimestamp time.Time
-
-	for eventID, sentEvent := range c.sentEvents {
-		if sentEvent.Type == eventType && sentEvent.CardID == cardID {
-			// Find most recent matching event (including self-events)
-			if sentEvent.Timestamp.After(latestTimestamp) {
-				matchingEventID = eventID
-				latestTimestamp = sentEvent.Timestamp
-			}
+func (c *EventCorrelator) RecordReceivedEvent(received ReceivedEvent) {
+	shard := c.shardFor(received.CardID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	key := eventKey{received.Type, received.CardID}
+	if event := match(shard.byKey[key], received.ReceiverID, received.Tags); event != nil {
+		if latency, ok := c.receive(shard, event, received.ReceiverID, received.Timestamp); ok && c.verbose {
+			fmt.Printf("📨 Event matched: %s for card %s by user %d (latency: %v)\n",
+				received.Type, received.CardID, received.ReceiverID, latency)
 		}
+	} else if shard.pendingCount < correlatorMaxPending {
+		// No matching sent event yet - add to pending
+		shard.pending[key] = append(shard.pending[key], pendingReceipt{
+			receiverID: received.ReceiverID,
+			at:         received.Timestamp,
+			tags:       received.Tags,
+		})
+		shard.pendingCount++
+		if c.verbose {
+			fmt.Printf("⏳ Pending event (will match later): %s for card %s by user %d\n",
+				received.Type, received.CardID, received.ReceiverID)
+		}
+	} else {
+		// Too many receipts are waiting to hold this one for its send
+		c.recordUnchecked()
 	}
 
-	if matchingEventID != "" {
-		// Record the reception
-		if receivers, ok := c.receivedEvents[matchingEventID]; ok {
-			// Only record if not already received by this user
-			if _, alreadyReceived := receivers[receiverID]; !alreadyReceived {
-				receivers[receiverID] = receiveTime
+	c.sweep(shard, time.Now())
+}
+
+// RecordDuplicate records a client receiving the same message twice
+func (c *EventCorrelator) RecordDuplicate(received ReceivedEvent) {
+	c.anomalyMu.Lock()
+	defer c.anomalyMu.Unlock()
+	c.anomalies.Duplicates++
+	c.anomalies.DuplicatesByUser[received.ReceiverID]++
+	if len(c.anomalies.DuplicateExamples) < anomalyMaxExamples {
+		c.anomalies.DuplicateExamples = append(c.anomalies.DuplicateExamples, DeliveryAnomaly{
+			Type: received.Type, CardID: received.CardID, UserID: received.ReceiverID, At: received.Timestamp,
+		})
+	}
+}
 
-				// Calculate latency
-				sentEvent := c.sentEvents[matchingEventID]
-				latency := receiveTime.Sub(sentEvent.Timestamp)
-				c.latencies = append(c.latencies, latency)
+// RecordOutOfOrder records a client receiving an event before one it depends on
+func (c *EventCorrelator) RecordOutOfOrder(received ReceivedEvent, detail string) {
+	c.anomalyMu.Lock()
+	defer c.anomalyMu.Unlock()
+	c.anomalies.OutOfOrder++
+	if len(c.anomalies.OutOfOrderExamples) < anomalyMaxExamples {
+		c.anomalies.OutOfOrderExamples = append(c.anomalies.OutOfOrderExamples, DeliveryAnomaly{
+			Type: received.Type, CardID: received.CardID, UserID: received.ReceiverID, At: received.Timestamp, Detail: detail,
+		})
+	}
+}
 
-				if c.verbose {
-					fmt.Printf("📨 Event matched: %s for card %s by user %d (latency: %v)\n",
-						eventType, cardID, receiverID, latency)
-				}
+// unmatched records a receipt that waited for its send in vain: a phantom
+// if sends of its type and card were recorded, since one of them should
+// have accounted for it. Otherwise the server broadcast something no
+// action records, and the receipt cannot be checked. Callers hold the
+// shard's lock.
+func (c *EventCorrelator) unmatched(shard *correlatorShard, key eventKey, receipt pendingReceipt) {
+	if shard.sentKeys[key] {
+		c.RecordPhantom(key.eventType, key.cardID, receipt.receiverID, receipt.at)
+	} else {
+		c.recordUnchecked()
+	}
+}
+
+// recordUnchecked counts a receipt that could not be checked against any send
+func (c *EventCorrelator) recordUnchecked() {
+	c.anomalyMu.Lock()
+	defer c.anomalyMu.Unlock()
+	c.anomalies.Unchecked++
+}
+
+// RecordPhantom records a receipt no sent event accounts for
+func (c *EventCorrelator) RecordPhantom(eventType, cardID string, receiverID int, at time.Time) {
+	c.anomalyMu.Lock()
+	defer c.anomalyMu.Unlock()
+	c.anomalies.Phantoms++
+	if len(c.anomalies.PhantomExamples) < anomalyMaxExamples {
+		c.anomalies.PhantomExamples = append(c.anomalies.PhantomExamples, DeliveryAnomaly{
+			Type: eventType, CardID: cardID, UserID: receiverID, At: at,
+		})
+	}
+}
+
+// receive records one receipt of a live event, unless receiverID already
+// has it. Receipts by users who were not connected to the sender's board
+// when the event was sent are counted apart. Callers hold the shard's lock.
+func (c *EventCorrelator) receive(shard *correlatorShard, event *trackedEvent, receiverID int, at time.Time) (time.Duration, bool) {
+	word, bit := receiverID/64, uint(receiverID%64)
+	for len(event.receivers) <= word {
+		event.receivers = append(event.receivers, 0)
+	}
+	if event.receivers[word]&(1<<bit) != 0 {
+		return 0, false
+	}
+	event.receivers[word] |= 1 << bit
+	if !event.Expected.Has(receiverID) {
+		shard.unexpected++
+		return 0, false
+	}
+
+	latency := at.Sub(event.Timestamp)
+	event.latencies = append(event.latencies, latency)
+	event.received++
+	if latency > event.slowest {
+		event.slowest = latency
+	}
+	shard.totals.receive(event, latency)
+	c.received.Add(1)
+	return latency, true
+}
+
+// sweep retires the shard's events past retention and drops receipts that
+// waited that long for a send. Callers hold the shard's lock.
+func (c *EventCorrelator) sweep(shard *correlatorShard, now time.Time) {
+	if now.Before(shard.nextSweep) {
+		return
+	}
+	shard.nextSweep = now.Add(correlatorSweep)
+	cutoff := now.Add(-correlatorRetention)
+
+	retired := 0
+	for _, event := range shard.order {
+		if !event.Timestamp.Before(cutoff) {
+			break
+		}
+		c.retire(shard, event)
+		retired++
+	}
+	shard.order = shard.order[retired:]
+
+	for key, receipts := range shard.pending {
+		kept := receipts[:0]
+		for _, receipt := range receipts {
+			if receipt.at.After(cutoff) {
+				kept = append(kept, receipt)
+			} else {
+				c.unmatched(shard, key, receipt)
 			}
 		}
-	} else {
-		// No matching sent event yet - add to pending
-		c.pendingReceived = append(c.pendingReceived, ReceivedEvent{
-			Type:       eventType,
-			CardID:     cardID,
-			ReceiverID: receiverID,
-			Timestamp:  receiveTime,
+		shard.pendingCount -= len(receipts) - len(kept)
+		if len(kept) == 0 {
+			delete(shard.pending, key)
+		} else {
+			shard.pending[key] = kept
+		}
+	}
+}
+
+// retire records the users who missed an event, adds it to any window
+// watching it and frees its receipts. Callers hold the shard's lock.
+func (c *EventCorrelator) retire(shard *correlatorShard, event *trackedEvent) {
+	if !event.AfterDelete {
+		event.missed(func(userID int) {
+			shard.missedByUser[userID]++
+			if len(shard.misses) < correlatorMaxMisses {
+				shard.misses = append(shard.misses, event.miss(userID))
+			}
 		})
-		if c.verbose {
-			fmt.Printf("⏳ Pending event (will match later): %s for card %s by user %d\n", eventType, cardID, receiverID)
+	}
+
+	c.windowMu.Lock()
+	for _, window := range c.windows {
+		if window.contains(event) {
+			window.stats.Add(event.delivery())
 		}
 	}
+	c.windowMu.Unlock()
+
+	delete(shard.live, event.ID)
+	if events := shard.byKey[event.key]; len(events) > 1 {
+		shard.byKey[event.key] = slices.DeleteFunc(events, func(e *trackedEvent) bool { return e == event })
+	} else {
+		delete(shard.byKey, event.key)
+	}
+	event.receivers = nil
+	event.latencies = nil
 }
 
-// GenerateReport generates the final test report
-func (c *EventCorrelator) GenerateReport(totalConnectedUsers int) *TestResult {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// miss describes userID missing the event
+func (e *trackedEvent) miss(userID int) MissedDelivery {
+	return MissedDelivery{EventID: e.ID, Type: e.Type, CardID: e.CardID, UserID: userID, SentAt: e.Timestamp}
+}
 
-	result := &TestResult{
-		ByType:              make(map[string]*EventTypeStats),
-		LatencyStats:        c.calculateLatencyStats(),
-		ConnectionStability: &ConnectionStats{},
+// delivery returns the event's delivery as a one-event DeliveryStats
+func (e *trackedEvent) delivery() *DeliveryStats {
+	stats := &DeliveryStats{Sent: 1, Expected: e.ConnectedUsers, Received: len(e.latencies)}
+	for _, latency := range e.latencies {
+		stats.AddLatency(latency)
 	}
+	return stats
+}
+
+// contains reports whether the window covers an event that counts toward delivery
+func (w *deliveryWindow) contains(event *trackedEvent) bool {
+	return !event.AfterDelete && !event.Timestamp.Before(w.from) && event.Timestamp.Before(w.to)
+}
 
-	// Calculate per-type statistics
-	typeCounts := make(map[string]*EventTypeStats)
+// deliveryTotals accumulates delivery as events are sent and received
+type deliveryTotals struct {
+	byType        map[string]*EventTypeStats
+	byPersona     map[string]*PersonaStats
+	byConcurrency map[int]*DeliveryStats
+	byPhase       map[string]*DeliveryStats
+	byBoard       map[string]*DeliveryStats
+	latency       *LatencyHistogram // Every receipt, including those of events sent after a delete
+}
 
-	for eventID, sentEvent := range c.sentEvents {
-		eventType := sentEvent.Type
+func newDeliveryTotals() *deliveryTotals {
+	return &deliveryTotals{
+		byType:        make(map[string]*EventTypeStats),
+		byPersona:     make(map[string]*PersonaStats),
+		byConcurrency: make(map[int]*DeliveryStats),
+		byPhase:       make(map[string]*DeliveryStats),
+		byBoard:       make(map[string]*DeliveryStats),
+		latency:       NewLatencyHistogram(),
+	}
+}
 
-		// Initialize type stats if needed
-		if _, ok := typeCounts[eventType]; !ok {
-			typeCounts[eventType] = &EventTypeStats{
-				Sent:     0,
-				Expected: 0,
-				Received: 0,
-				Missed:   0,
-			}
+// send counts one sent event
+func (t *deliveryTotals) send(event *trackedEvent) {
+	stats, ok := t.byType[event.Type]
+	if !ok {
+		stats = &EventTypeStats{}
+		t.byType[event.Type] = stats
+	}
+	stats.Sent++
+
+	// Events sent for an already deleted card count as neither expected nor received
+	if event.AfterDelete {
+		stats.AfterDelete++
+		return
+	}
+
+	// Expected receivers: all users connected AT THE TIME this event was sent
+	expected := event.ConnectedUsers
+	stats.Expected += expected
+
+	// Drivers (sender 0) play no persona
+	if event.persona != "" {
+		persona, ok := t.byPersona[event.persona]
+		if !ok {
+			persona = &PersonaStats{}
+			t.byPersona[event.persona] = persona
+		}
+		persona.Sent++
+		persona.Expected += expected
+	}
+
+	concurrency, ok := t.byConcurrency[event.ConnectedUsers]
+	if !ok {
+		concurrency = &DeliveryStats{}
+		t.byConcurrency[event.ConnectedUsers] = concurrency
+	}
+	concurrency.Sent++
+	concurrency.Expected += expected
+
+	if event.Phase != "" {
+		phase, ok := t.byPhase[event.Phase]
+		if !ok {
+			phase = &DeliveryStats{}
+			t.byPhase[event.Phase] = phase
+		}
+		phase.Sent++
+		phase.Expected += expected
+	}
+
+	board, ok := t.byBoard[event.BoardID]
+	if !ok {
+		board = &DeliveryStats{}
+		t.byBoard[event.BoardID] = board
+	}
+	board.Sent++
+	board.Expected += expected
+}
+
+// receive counts one receipt of a sent event
+func (t *deliveryTotals) receive(event *trackedEvent, latency time.Duration) {
+	t.latency.Add(latency)
+	if event.AfterDelete {
+		return
+	}
+	t.byType[event.Type].Received++
+	if event.persona != "" {
+		t.byPersona[event.persona].Received++
+	}
+	concurrency := t.byConcurrency[event.ConnectedUsers]
+	concurrency.Received++
+	concurrency.AddLatency(latency)
+	if event.Phase != "" {
+		phase := t.byPhase[event.Phase]
+		phase.Received++
+		phase.AddLatency(latency)
+	}
+	board := t.byBoard[event.BoardID]
+	board.Received++
+	board.AddLatency(latency)
+}
+
+// merge adds other's totals into t
+func (t *deliveryTotals) merge(other *deliveryTotals) {
+	for eventType, stats := range other.byType {
+		into, ok := t.byType[eventType]
+		if !ok {
+			into = &EventTypeStats{}
+			t.byType[eventType] = into
+		}
+		into.Sent += stats.Sent
+		into.Expected += stats.Expected
+		into.Received += stats.Received
+		into.AfterDelete += stats.AfterDelete
+	}
+	for name, stats := range other.byPersona {
+		into, ok := t.byPersona[name]
+		if !ok {
+			into = &PersonaStats{}
+			t.byPersona[name] = into
+		}
+		into.Sent += stats.Sent
+		into.Expected += stats.Expected
+		into.Received += stats.Received
+	}
+	for users, stats := range other.byConcurrency {
+		into, ok := t.byConcurrency[users]
+		if !ok {
+			into = &DeliveryStats{}
+			t.byConcurrency[users] = into
+		}
+		into.Add(stats)
+	}
+	for phase, stats := range other.byPhase {
+		into, ok := t.byPhase[phase]
+		if !ok {
+			into = &DeliveryStats{}
+			t.byPhase[phase] = into
+		}
+		into.Add(stats)
+	}
+	for boardID, stats := range other.byBoard {
+		into, ok := t.byBoard[boardID]
+		if !ok {
+			into = &DeliveryStats{}
+			t.byBoard[boardID] = into
 		}
+		into.Add(stats)
+	}
+	t.latency.Merge(other.latency)
+}
 
-		stats := typeCounts[eventType]
-		stats.Sent++
+// GenerateReport generates the final test report
+func (c *EventCorrelator) GenerateReport(totalConnectedUsers int) *TestResult {
+	totals := newDeliveryTotals()
+	var misses []MissedDelivery
+	missedByUser := make(map[int]int)
+	unexpected := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		totals.merge(shard.totals)
+		misses = append(misses, shard.misses...)
+		for userID, count := range shard.missedByUser {
+			missedByUser[userID] += count
+		}
+		unexpected += shard.unexpected
 
-		// Expected receivers: all users connected AT THE TIME this event was sent
-		expectedReceivers := sentEvent.ConnectedUsers
-		stats.Expected += expectedReceivers
+		// Receipts still waiting for a send will not get one now
+		for key, receipts := range shard.pending {
+			for _, receipt := range receipts {
+				c.unmatched(shard, key, receipt)
+			}
+		}
+		shard.pendingCount = 0
+		clear(shard.pending)
 
-		// Actual receivers
-		if receivers, ok := c.receivedEvents[eventID]; ok {
-			actualReceivers := len(receivers)
-			stats.Received += actualReceivers
+		// Events still within retention are final too once the run is over
+		for _, event := range shard.order {
+			if event.AfterDelete {
+				continue
+			}
+			event.missed(func(userID int) {
+				missedByUser[userID]++
+				misses = append(misses, event.miss(userID))
+			})
 		}
+		shard.mu.Unlock()
+	}
+	sort.Slice(misses, func(i, j int) bool { return misses[i].SentAt.Before(misses[j].SentAt) })
+	if len(misses) > correlatorMaxMisses {
+		misses = misses[:correlatorMaxMisses]
+	}
+
+	c.stateMu.RLock()
+	phaseOrder := append([]string{}, c.phaseOrder...)
+	c.stateMu.RUnlock()
+
+	result := &TestResult{
+		ByType:              totals.byType,
+		ByConcurrency:       totals.byConcurrency,
+		ByPersona:           totals.byPersona,
+		ByPhase:             totals.byPhase,
+		ByBoard:             totals.byBoard,
+		PhaseOrder:          phaseOrder,
+		Misses:              misses,
+		MissedByUser:        missedByUser,
+		Unexpected:          unexpected,
+		LatencyStats:        totals.latency.Stats(),
+		ConnectionStability: &ConnectionStats{},
 	}
 
 	// Calculate rates and missed counts
-	for _, stats := range typeCounts {
+	for _, stats := range result.ByType {
 		stats.Missed = stats.Expected - stats.Received
 		if stats.Expected > 0 {
 			stats.Rate = float64(stats.Received) / float64(stats.Expected) * 100.0
@@ -172,36 +743,33 @@ func (c *EventCorrelator) GenerateReport(totalConnectedUsers int) *TestResult {
 		}
 	}
 
-	result.ByType = typeCounts
-
 	// Calculate overall stats
-	result.EventsSent = len(c.sentEvents)
-	for _, stats := range typeCounts {
+	for _, stats := range result.ByType {
+		result.EventsSent += stats.Sent
 		result.EventsExpected += stats.Expected
 		result.EventsReceived += stats.Received
 	}
 
+	c.anomalyMu.Lock()
+	anomalies := c.anomalies
+	c.anomalyMu.Unlock()
+	result.Anomalies = &anomalies
+
 	return result
 }
 
-// calculateLatencyStats calculates latency percentiles
-func (c *EventCorrelator) calculateLatencyStats() *LatencyStats {
-	if len(c.latencies) == 0 {
+// computeLatencyStats calculates mean, max and percentiles for a set of latencies
+func computeLatencyStats(latencies []time.Duration) *LatencyStats {
+	if len(latencies) == 0 {
 		return &LatencyStats{}
 	}
 
 	// Sort latencies for percentile calculation
-	sortedLatencies := make([]time.Duration, len(c.latencies))
-	copy(sortedLatencies, c.latencies)
-
-	// Simple bubble sort (good enough for our use case)
-	for i := 0; i < len(sortedLatencies); i++ {
-		for j := i + 1; j < len(sortedLatencies); j++ {
-			if sortedLatencies[i] > sortedLatencies[j] {
-				sortedLatencies[i], sortedLatencies[j] = sortedLatencies[j], sortedLatencies[i]
-			}
-		}
-	}
+	sortedLatencies := make([]time.Duration, len(latencies))
+	copy(sortedLatencies, latencies)
+	sort.Slice(sortedLatencies, func(i, j int) bool {
+		return sortedLatencies[i] < sortedLatencies[j]
+	})
 
 	// Calculate statistics
 	var sum time.Duration
@@ -226,19 +794,112 @@ func (c *EventCorrelator) calculateLatencyStats() *LatencyStats {
 
 // GetStats returns current statistics (for monitoring during test)
 func (c *EventCorrelator) GetStats() (sent, received int) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	return int(c.sent.Load()), int(c.received.Load())
+}
+
+// SetUserPersona records which persona a user plays so delivery can be reported per persona
+func (c *EventCorrelator) SetUserPersona(userID int, persona string) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.personas[userID] = persona
+}
 
-	sent = len(c.sentEvents)
-	for _, receivers := range c.receivedEvents {
-		received += len(receivers)
+// SetPhase attributes events sent from now on to phase (a meeting scene)
+func (c *EventCorrelator) SetPhase(phase string) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.phase = phase
+	for _, seen := range c.phaseOrder {
+		if seen == phase {
+			return
+		}
+	}
+	c.phaseOrder = append(c.phaseOrder, phase)
+}
+
+// DeliveryFor returns how many users were expected to receive one sent
+// event, how many did, and how long the slowest receiver took. Events sent
+// by users are only kept for correlatorRetention; those sent by drivers
+// (sender 0) are kept for the whole run.
+func (c *EventCorrelator) DeliveryFor(eventID string) (expected, received int, slowest time.Duration) {
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		event, ok := shard.live[eventID]
+		if !ok {
+			event, ok = shard.drivers[eventID]
+		}
+		if ok {
+			expected, received, slowest = event.ConnectedUsers, event.received, event.slowest
+		}
+		shard.mu.Unlock()
+		if ok {
+			return expected, received, slowest
+		}
+	}
+	return 0, 0, 0
+}
+
+// WatchDelivery keeps the delivery of events sent from from up to to past
+// retention, for DeliveryBetween. It must be called within
+// correlatorRetention of from.
+func (c *EventCorrelator) WatchDelivery(from, to time.Time) {
+	c.windowMu.Lock()
+	defer c.windowMu.Unlock()
+	c.windows = append(c.windows, &deliveryWindow{from: from, to: to, stats: &DeliveryStats{}})
+}
+
+// DeliveryBetween totals delivery of the events sent from from up to to,
+// with the latency of every receipt. Events past retention are only
+// included if the window was watched.
+func (c *EventCorrelator) DeliveryBetween(from, to time.Time) *DeliveryStats {
+	// Hold every shard so no event retires into a window while it is read
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
 	}
-	return
+
+	stats := &DeliveryStats{}
+	c.windowMu.Lock()
+	for _, window := range c.windows {
+		if window.from.Equal(from) && window.to.Equal(to) {
+			stats.Add(window.stats)
+			break
+		}
+	}
+	c.windowMu.Unlock()
+
+	window := &deliveryWindow{from: from, to: to}
+	for _, shard := range c.shards {
+		for _, event := range shard.order {
+			if window.contains(event) {
+				stats.Add(event.delivery())
+			}
+		}
+	}
+	return stats
+}
+
+// SetUserBoard records the board a user joins, which decides who is
+// expected to receive what they send. Drivers (user 0) act on one board.
+func (c *EventCorrelator) SetUserBoard(userID int, boardID string) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.boards[userID] = boardID
+}
+
+// UserConnected adds a user to those expected to receive events sent on
+// their board from now on
+func (c *EventCorrelator) UserConnected(userID int) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	boardID := c.boards[userID]
+	c.connected[boardID] = c.connected[boardID].With(userID)
 }
 
-// SetConnectedUsers updates the current count of connected users
-func (c *EventCorrelator) SetConnectedUsers(count int) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.connectedUsers = count
+// UserDisconnected stops expecting a user to receive events sent from now on
+func (c *EventCorrelator) UserDisconnected(userID int) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	boardID := c.boards[userID]
+	c.connected[boardID] = c.connected[boardID].Without(userID)
 }
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fleetSetupWorkers is how many boards are set up at once
+const fleetSetupWorkers = 8
+
+// fleetWorstBoards is how many boards the report lists, worst first, without -verbose
+const fleetWorstBoards = 10
+
+// ParseBoardUsers parses a per-board user range such as "5-12", or a single count such as "8"
+func ParseBoardUsers(spec string) (low, high int, err error) {
+	lowStr, highStr, ranged := strings.Cut(spec, "-")
+	low, err = strconv.Atoi(strings.TrimSpace(lowStr))
+	if err != nil {
+		return 0, 0, fmt.Errorf("board users %q: invalid count: %w", spec, err)
+	}
+	high = low
+	if ranged {
+		high, err = strconv.Atoi(strings.TrimSpace(highStr))
+		if err != nil {
+			return 0, 0, fmt.Errorf("board users %q: invalid count: %w", spec, err)
+		}
+	}
+	if low < 1 || high < low {
+		return 0, 0, fmt.Errorf("board users %q: need 1 or more, low to high", spec)
+	}
+	return low, high, nil
+}
+
+// SampleBoardSizes picks how many users each of boards boards gets,
+// uniformly between low and high
+func SampleBoardSizes(boards, low, high int) []int {
+	sizes := make([]int, boards)
+	for i := range sizes {
+		sizes[i] = low + rand.Intn(high-low+1)
+	}
+	return sizes
+}
+
+// roomiestBoard returns the board with the most room left for another user,
+// given how many users each board has now. Boards without a size have none
+// to spare, so they are filled evenly; ties go to the earliest board.
+func roomiestBoard(boards []*BoardTarget, live map[string]int) *BoardTarget {
+	best := boards[0]
+	for _, board := range boards[1:] {
+		if board.Size-live[board.ID] > best.Size-live[best.ID] {
+			best = board
+		}
+	}
+	return best
+}
+
+// NewFleetReport breaks delivery down by board and summarizes the spread
+// across boards. users maps each board to the users who connected to it.
+func NewFleetReport(boards []*BoardTarget, series int, byBoard map[string]*DeliveryStats, users map[string]int) *FleetReport {
+	report := &FleetReport{Series: series}
+	var sizes []int
+	var rates []float64
+	var p50s, p95s []time.Duration
+	for i, board := range boards {
+		delivery := BoardDelivery{Index: i + 1, ID: board.ID, Users: users[board.ID], Rate: 100, Latency: &LatencyStats{}}
+		if stats := byBoard[board.ID]; stats != nil {
+			delivery.Sent, delivery.Expected, delivery.Received = stats.Sent, stats.Expected, stats.Received
+			if stats.Expected > 0 {
+				delivery.Rate = float64(stats.Received) / float64(stats.Expected) * 100
+			}
+			delivery.Latency = stats.LatencyStats()
+		}
+		report.Boards = append(report.Boards, delivery)
+
+		sizes = append(sizes, delivery.Users)
+		if delivery.Expected > 0 {
+			rates = append(rates, delivery.Rate)
+		}
+		if delivery.Latency.Count > 0 {
+			p50s = append(p50s, delivery.Latency.P50)
+			p95s = append(p95s, delivery.Latency.P95)
+		}
+	}
+
+	// Worst boards first; ties go to the slower board
+	sort.SliceStable(report.Boards, func(i, j int) bool {
+		a, b := report.Boards[i], report.Boards[j]
+		if a.Rate != b.Rate {
+			return a.Rate < b.Rate
+		}
+		return a.Latency.P95 > b.Latency.P95
+	})
+
+	sort.Ints(sizes)
+	if len(sizes) > 0 {
+		report.UsersMin, report.UsersMedian, report.UsersMax = sizes[0], sizes[len(sizes)/2], sizes[len(sizes)-1]
+	}
+	sort.Float64s(rates)
+	if len(rates) > 0 {
+		report.RateWorst = rates[0]
+		report.RateP10 = rates[len(rates)/10]
+		report.RateMedian = rates[len(rates)/2]
+	}
+	report.P50 = newLatencySpread(p50s)
+	report.P95 = newLatencySpread(p95s)
+	return report
+}
+
+// newLatencySpread summarizes one latency figure taken from every board
+func newLatencySpread(values []time.Duration) LatencySpread {
+	if len(values) == 0 {
+		return LatencySpread{}
+	}
+	sorted := append([]time.Duration{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	at := func(p float64) time.Duration {
+		return sorted[min(int(float64(len(sorted))*p), len(sorted)-1)]
+	}
+	return LatencySpread{Median: at(0.50), P90: at(0.90), P99: at(0.99), Max: sorted[len(sorted)-1]}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Health survey timing
+const (
+	healthSettleDelay  = 2 * time.Second        // Lets clients handle the scene change before the first burst
+	healthBarrierDelay = 500 * time.Millisecond // Lead time so every user fires at the same instant
+	healthPollInterval = 250 * time.Millisecond
+	healthPollTimeout  = 30 * time.Second
+)
+
+// healthRound coordinates one burst of survey answers across all users
+type healthRound struct {
+	number       int
+	sceneID      string
+	questions    []HealthQuestion
+	ratings      map[string]int // questionID -> rating every user gives this round
+	submitted    sync.WaitGroup
+	allSubmitted chan bool
+	answerers    map[string]map[int]bool // questionID -> users whose answer was accepted
+	mu           sync.Mutex
+}
+
+// newHealthRound prepares round number for the given number of users
+func newHealthRound(number int, sceneID string, questions []HealthQuestion, users int) *healthRound {
+	round := &healthRound{
+		number:       number,
+		sceneID:      sceneID,
+		questions:    questions,
+		ratings:      make(map[string]int),
+		allSubmitted: make(chan bool),
+		answerers:    make(map[string]map[int]bool),
+	}
+	for _, q := range questions {
+		round.ratings[q.ID] = healthRating(q.QuestionType, number)
+		round.answerers[q.ID] = make(map[int]bool)
+	}
+
+	round.submitted.Add(users)
+	go func() {
+		round.submitted.Wait()
+		close(round.allSubmitted)
+	}()
+	return round
+}
+
+// healthRating returns a rating that is valid for questionType and changes
+// every round, so a stale result is distinguishable from a fresh one
+func healthRating(questionType string, round int) int {
+	if questionType == "boolean" {
+		return round % 2
+	}
+	// 1, 3 and 5 are valid for every range and traffic-light question
+	return []int{1, 3, 5}[round%3]
+}
+
+// recordAnswer stores whether userID's answer to questionID was accepted
+func (r *healthRound) recordAnswer(questionID string, userID int, err error) {
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.answerers[questionID][userID] = true
+}
+
+// converged reports whether results show every accepted answer from this round
+func (r *healthRound) converged(results []HealthResult) bool {
+	select {
+	case <-r.allSubmitted:
+	default:
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byQuestion := make(map[string]HealthResult, len(results))
+	for _, result := range results {
+		byQuestion[result.Question.ID] = result
+	}
+	for _, q := range r.questions {
+		result, ok := byQuestion[q.ID]
+		if !ok {
+			return false
+		}
+		if result.Distribution[strconv.Itoa(r.ratings[q.ID])] < len(r.answerers[q.ID]) {
+			return false
+		}
+	}
+	return true
+}
+
+// healthUserResult is one user's view of a round
+type healthUserResult struct {
+	submitLatencies []time.Duration
+	failures        int
+	duplicateFailed bool
+	completionLag   time.Duration // Own submit done until completion-status said completed
+	resultsLag      time.Duration // Own submit done until results showed everyone's answers
+	converged       bool
+}
+
+// HealthSurvey switches the board into a survey scene and has every
+// connected user answer it at the same instant, round after round
+type HealthSurvey struct {
+	api        *APIClient
+	boardID    string
+	scene      Scene
+	presetID   string
+	rounds     int
+	interval   time.Duration
+	pool       *UserPool
+	meeting    *MeetingState
+	correlator *EventCorrelator
+	questions  []HealthQuestion
+	answered   map[string]map[int]bool // questionID -> users that ever answered
+	report     HealthReport
+}
+
+// NewHealthSurvey creates a survey driver for scene, a survey scene on the board
+func NewHealthSurvey(api *APIClient, boardID string, scene Scene, config *Config, pool *UserPool,
+	meeting *MeetingState, correlator *EventCorrelator) *HealthSurvey {
+	rounds := config.HealthRounds
+	if rounds < 1 {
+		rounds = 1
+	}
+	return &HealthSurvey{
+		api:        api,
+		boardID:    boardID,
+		scene:      scene,
+		presetID:   config.HealthPreset,
+		rounds:     rounds,
+		interval:   config.TestDuration / time.Duration(rounds),
+		pool:       pool,
+		meeting:    meeting,
+		correlator: correlator,
+		answered:   make(map[string]map[int]bool),
+		report:     HealthReport{Preset: config.HealthPreset, Scene: scene.Title},
+	}
+}
+
+// Run moves the board to the survey scene, applies the question preset and
+// runs each answer burst until every round is done or stopChan closes
+func (h *HealthSurvey) Run(stopChan <-chan bool) {
+	started := time.Now()
+
+	if err := h.api.ChangeScene(h.boardID, h.scene.ID); err != nil {
+		h.fail(fmt.Errorf("change to survey scene: %w", err))
+		return
+	}
+	h.correlator.RecordSentEvent("scene_changed", h.scene.ID, 0)
+	h.meeting.SetScene(h.scene)
+
+	questions, err := h.api.ApplyHealthPreset(h.scene.ID, h.presetID)
+	if err != nil {
+		h.fail(fmt.Errorf("apply preset %q: %w", h.presetID, err))
+		return
+	}
+	h.report.PresetEventID = h.correlator.RecordSentEvent("scene_updated", h.scene.ID, 0)
+	h.questions = questions
+	h.report.Questions = len(questions)
+	for _, q := range questions {
+		h.answered[q.ID] = make(map[int]bool)
+	}
+	PrintInfo("Health", fmt.Sprintf("Survey scene %q ready with %d questions from %s", h.scene.Title, len(questions), h.presetID))
+
+	for number := 1; number <= h.rounds; number++ {
+		next := started.Add(healthSettleDelay + time.Duration(number-1)*h.interval)
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		report := h.runRound(number)
+		h.report.Rounds = append(h.report.Rounds, report)
+		PrintInfo("Health", fmt.Sprintf("Round %d: %d users, %d answers (%d failed), results converged for %d/%d users",
+			number, report.Users, report.Answers, report.Failed, report.Users-report.Unconverged, report.Users))
+	}
+}
+
+// runRound fires one synchronized burst of answers and waits for every user
+// to see the results
+func (h *HealthSurvey) runRound(number int) HealthRoundReport {
+	var users []*UserSimulator
+	for _, u := range h.pool.Users() {
+		if u.IsConnected() {
+			users = append(users, u)
+		}
+	}
+
+	round := newHealthRound(number, h.scene.ID, h.questions, len(users))
+	start := time.Now().Add(healthBarrierDelay)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var userResults []healthUserResult
+	for _, u := range users {
+		wg.Add(1)
+		go func(u *UserSimulator) {
+			defer wg.Done()
+			result := u.answerHealthSurvey(round, start)
+			mu.Lock()
+			userResults = append(userResults, result)
+			mu.Unlock()
+		}(u)
+	}
+	wg.Wait()
+
+	report := HealthRoundReport{Round: number, Users: len(users)}
+	var submitLatencies, completionLags, resultsLags []time.Duration
+	for _, result := range userResults {
+		report.Answers += len(result.submitLatencies)
+		report.Failed += result.failures
+		if result.duplicateFailed {
+			report.DuplicateFailed++
+		}
+		submitLatencies = append(submitLatencies, result.submitLatencies...)
+		if result.converged {
+			completionLags = append(completionLags, result.completionLag)
+			resultsLags = append(resultsLags, result.resultsLag)
+		} else {
+			report.Unconverged++
+		}
+	}
+	report.SubmitLatency = computeLatencyStats(submitLatencies)
+	report.CompletionLag = computeLatencyStats(completionLags)
+	report.ResultsLag = computeLatencyStats(resultsLags)
+
+	// More stored responses than users who ever answered means the unique
+	// constraint on (question, user) let a duplicate through
+	for questionID, answerers := range round.answerers {
+		for userID := range answerers {
+			h.answered[questionID][userID] = true
+		}
+	}
+	results, err := h.api.GetHealthResults(h.scene.ID)
+	if err != nil {
+		PrintWarning("Health", fmt.Sprintf("Round %d: could not check for duplicate responses: %v", number, err))
+		return report
+	}
+	for _, result := range results {
+		if extra := result.TotalResponses - len(h.answered[result.Question.ID]); extra > 0 {
+			report.Duplicates += extra
+		}
+	}
+	return report
+}
+
+// Report returns the survey results, including delivery of the preset's scene_updated broadcast
+func (h *HealthSurvey) Report() *HealthReport {
+	report := h.report
+	if report.PresetEventID != "" {
+		report.PresetExpected, report.PresetReceived, report.PresetFanOut = h.correlator.DeliveryFor(report.PresetEventID)
+	}
+	return &report
+}
+
+func (h *HealthSurvey) fail(err error) {
+	h.report.Error = err.Error()
+	PrintError("Health", err.Error())
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// histogramSubBuckets splits every power of two into this many buckets, so
+// a recorded latency is kept to within about 3%
+const (
+	histogramSubBuckets = 32
+	histogramSubBits    = 5 // log2(histogramSubBuckets)
+)
+
+// LatencyHistogram counts latencies in log-linear buckets. Unlike a slice
+// of every latency its size depends on the spread of the values rather than
+// their number, so a long soak can keep millions of deliveries. Count, Mean
+// and Max are exact; percentiles are read from the bucket they fall in.
+type LatencyHistogram struct {
+	counts map[int]uint64 // bucket -> latencies in it
+	count  int
+	sum    time.Duration
+	max    time.Duration
+}
+
+// NewLatencyHistogram creates an empty histogram
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{counts: make(map[int]uint64)}
+}
+
+// Add records one latency
+func (h *LatencyHistogram) Add(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	h.counts[histogramBucket(d)]++
+	h.count++
+	h.sum += d
+	if d > h.max {
+		h.max = d
+	}
+}
+
+// Merge adds every latency recorded in other
+func (h *LatencyHistogram) Merge(other *LatencyHistogram) {
+	if other == nil {
+		return
+	}
+	for bucket, count := range other.counts {
+		h.counts[bucket] += count
+	}
+	h.count += other.count
+	h.sum += other.sum
+	if other.max > h.max {
+		h.max = other.max
+	}
+}
+
+// Count returns how many latencies were recorded
+func (h *LatencyHistogram) Count() int {
+	if h == nil {
+		return 0
+	}
+	return h.count
+}
+
+// Stats returns mean, max and percentiles, in the same shape as
+// computeLatencyStats returns for a slice
+func (h *LatencyHistogram) Stats() *LatencyStats {
+	if h == nil || h.count == 0 {
+		return &LatencyStats{}
+	}
+
+	buckets := make([]int, 0, len(h.counts))
+	for bucket := range h.counts {
+		buckets = append(buckets, bucket)
+	}
+	sort.Ints(buckets)
+
+	percentile := func(q float64) time.Duration {
+		rank := uint64(float64(h.count) * q)
+		var seen uint64
+		for _, bucket := range buckets {
+			seen += h.counts[bucket]
+			if seen > rank {
+				return min(histogramValue(bucket), h.max)
+			}
+		}
+		return h.max
+	}
+
+	return &LatencyStats{
+		Count: h.count,
+		Mean:  h.sum / time.Duration(h.count),
+		Max:   h.max,
+		P50:   percentile(0.50),
+		P90:   percentile(0.90),
+		P95:   percentile(0.95),
+		P99:   percentile(0.99),
+	}
+}
+
+// histogramBucket returns the bucket for d. Values below
+// 2*histogramSubBuckets nanoseconds get a bucket each.
+func histogramBucket(d time.Duration) int {
+	v := uint64(d)
+	if v < histogramSubBuckets {
+		return int(v)
+	}
+	shift := bits.Len64(v) - 1 - histogramSubBits
+	return (shift+1)*histogramSubBuckets + int(v>>shift) - histogramSubBuckets
+}
+
+// histogramValue returns the middle of a bucket
+func histogramValue(bucket int) time.Duration {
+	if bucket < histogramSubBuckets {
+		return time.Duration(bucket)
+	}
+	shift := bucket/histogramSubBuckets - 1
+	lower := uint64(bucket%histogramSubBuckets+histogramSubBuckets) << shift
+	return time.Duration(lower + (uint64(1)<<shift)/2)
+}
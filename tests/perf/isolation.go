@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// isolationMaxExamples caps the leaks kept for the report
+const isolationMaxExamples = 10
+
+// IsolationChecker checks that what each client receives belongs to the
+// board it joined: every payload's board_id, and every user a presence list
+// shows. A leaked payload is reported here and dropped, so it is never
+// correlated or applied to the client's copy of the board.
+type IsolationChecker struct {
+	boards map[string]string // Server user ID -> board they joined
+	report IsolationReport
+	mu     sync.Mutex
+}
+
+// NewIsolationChecker creates a checker for a run over boards boards
+func NewIsolationChecker(boards int) *IsolationChecker {
+	return &IsolationChecker{
+		boards: make(map[string]string),
+		report: IsolationReport{
+			Boards:      boards,
+			LeaksByType: make(map[string]int),
+		},
+	}
+}
+
+// RegisterUser records the board a server user joins, before they join it
+func (i *IsolationChecker) RegisterUser(serverUserID, boardID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.boards[serverUserID] = boardID
+}
+
+// CheckPayload reports whether a payload receiverID received on boardID
+// was for another board. Payloads without a board_id cannot be checked
+// here; the correlator still keeps them from counting as deliveries.
+func (i *IsolationChecker) CheckPayload(receiverID int, boardID, eventType string, data map[string]interface{}) bool {
+	from, _ := data["board_id"].(string)
+	if from == "" {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.report.Checked++
+	if from == boardID {
+		return false
+	}
+	i.report.Leaks++
+	i.report.LeaksByType[eventType]++
+	i.example(IsolationLeak{Type: eventType, UserID: receiverID, Board: boardID, From: from, At: time.Now()})
+	return true
+}
+
+// CheckPresence checks that a presence list receiverID received on boardID
+// only shows users who joined that board. Users the checker does not know,
+// such as the admin, are left alone.
+func (i *IsolationChecker) CheckPresence(receiverID int, boardID, eventType string, listed []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.report.PresenceChecked++
+
+	var foreign []string
+	from := ""
+	for _, serverUserID := range listed {
+		if joined, ok := i.boards[serverUserID]; ok && joined != boardID {
+			foreign = append(foreign, serverUserID)
+			from = joined
+		}
+	}
+	if len(foreign) == 0 {
+		return
+	}
+	i.report.PresenceLeaks++
+	i.example(IsolationLeak{
+		Type:   eventType,
+		UserID: receiverID,
+		Board:  boardID,
+		From:   from,
+		Detail: fmt.Sprintf("lists %s from another board", strings.Join(foreign, ", ")),
+		At:     time.Now(),
+	})
+}
+
+// example keeps leak if there is room. Callers hold the lock.
+func (i *IsolationChecker) example(leak IsolationLeak) {
+	if len(i.report.Examples) < isolationMaxExamples {
+		i.report.Examples = append(i.report.Examples, leak)
+	}
+}
+
+// Report returns what was checked and every leak found
+func (i *IsolationChecker) Report() *IsolationReport {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	report := i.report
+	report.LeaksByType = make(map[string]int, len(i.report.LeaksByType))
+	for eventType, count := range i.report.LeaksByType {
+		report.LeaksByType[eventType] = count
+	}
+	report.Examples = append([]IsolationLeak{}, i.report.Examples...)
+	return &report
+}
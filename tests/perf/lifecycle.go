@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MeetingState is the scene a scripted meeting is currently in. Users read it
+// to decide which actions are allowed and record how long their board
+// refetch took after each scene change.
+type MeetingState struct {
+	scene           Scene
+	refetches       map[string][]time.Duration // scene title -> refetch latencies
+	refetchFailures map[string]int
+	mu              sync.RWMutex
+}
+
+// NewMeetingState creates a meeting state starting in scene
+func NewMeetingState(scene Scene) *MeetingState {
+	return &MeetingState{
+		scene:           scene,
+		refetches:       make(map[string][]time.Duration),
+		refetchFailures: make(map[string]int),
+	}
+}
+
+// SetScene records that the board moved to scene
+func (m *MeetingState) SetScene(scene Scene) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scene = scene
+}
+
+// CurrentScene returns the scene the meeting is in
+func (m *MeetingState) CurrentScene() (Scene, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.scene, m.scene.ID != ""
+}
+
+// Allows reports whether the current scene has flag set
+func (m *MeetingState) Allows(flag string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.scene.HasFlag(flag)
+}
+
+// RecordRefetch stores one user's board reload after a scene change
+func (m *MeetingState) RecordRefetch(sceneTitle string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.refetchFailures[sceneTitle]++
+		return
+	}
+	m.refetches[sceneTitle] = append(m.refetches[sceneTitle], latency)
+}
+
+// sceneTransition is one scene change issued by the driver
+type sceneTransition struct {
+	scene     Scene
+	eventID   string
+	changedAt time.Time
+	err       error
+}
+
+// LifecycleDriver walks a board through every scene in order, the way a
+// facilitator runs a retrospective, holding each scene for a fixed time
+type LifecycleDriver struct {
+	api           *APIClient
+	boardID       string
+	scenes        []Scene
+	sceneDuration time.Duration
+	meeting       *MeetingState
+	correlator    *EventCorrelator
+	transitions   []sceneTransition
+}
+
+// NewLifecycleDriver creates a driver for the board's scenes using the admin API client
+func NewLifecycleDriver(api *APIClient, boardID string, scenes []Scene, sceneDuration time.Duration,
+	meeting *MeetingState, correlator *EventCorrelator) *LifecycleDriver {
+	return &LifecycleDriver{
+		api:           api,
+		boardID:       boardID,
+		scenes:        scenes,
+		sceneDuration: sceneDuration,
+		meeting:       meeting,
+		correlator:    correlator,
+	}
+}
+
+// Run changes scene, waits sceneDuration, and repeats until every scene has
+// been visited or stopChan closes
+func (d *LifecycleDriver) Run(stopChan <-chan bool) {
+	for i, scene := range d.scenes {
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+
+		transition := sceneTransition{scene: scene, changedAt: time.Now()}
+		if err := d.api.ChangeScene(d.boardID, scene.ID); err != nil {
+			transition.err = err
+			PrintWarning("Lifecycle", fmt.Sprintf("Failed to change to scene %q: %v", scene.Title, err))
+		} else {
+			// Attribute everything sent from now on to the new scene
+			d.correlator.SetPhase(scene.Title)
+			transition.eventID = d.correlator.RecordSentEvent("scene_changed", scene.ID, 0)
+			d.meeting.SetScene(scene)
+			PrintInfo("Lifecycle", fmt.Sprintf("Scene %d/%d: %s (%s) flags=%v",
+				i+1, len(d.scenes), scene.Title, scene.Mode, scene.Flags))
+		}
+		d.transitions = append(d.transitions, transition)
+
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(d.sceneDuration):
+		}
+	}
+}
+
+// Report summarises each scene transition: how the scene_changed broadcast
+// fanned out and how long the resulting board refetches took
+func (d *LifecycleDriver) Report() []SceneReport {
+	d.meeting.mu.RLock()
+	defer d.meeting.mu.RUnlock()
+
+	reports := make([]SceneReport, 0, len(d.transitions))
+	for _, transition := range d.transitions {
+		report := SceneReport{
+			Title:           transition.scene.Title,
+			Mode:            transition.scene.Mode,
+			Refetch:         computeLatencyStats(d.meeting.refetches[transition.scene.Title]),
+			RefetchFailures: d.meeting.refetchFailures[transition.scene.Title],
+		}
+		if transition.err != nil {
+			report.Error = transition.err.Error()
+		}
+		if transition.eventID != "" {
+			report.Expected, report.Received, report.FanOut = d.correlator.DeliveryFor(transition.eventID)
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
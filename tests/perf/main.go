@@ -23,8 +23,129 @@ func main() {
 	flag.StringVar(&config.AdminEmail, "admin-email", "", "Admin account email (default: auto-generated)")
 	flag.BoolVar(&config.Verbose, "verbose", false, "Enable verbose logging")
 	flag.BoolVar(&config.Debug, "debug", false, "Enable debug logging (shows API requests/responses)")
+	flag.StringVar(&config.ScenarioFile, "scenario", "", "Scenario file (YAML or JSON); command-line flags override its values")
+	flag.StringVar(&config.Template, "template", "basic", "Board template to set up")
+	flag.StringVar(&config.Pacing, "pacing", PacingShared, "Action pacing: shared (one ticker for all users), constant or poisson (open model), think (per-user think time)")
+	flag.StringVar(&config.Workload, "workload", WorkloadRandom, "Workload: random (weighted actions on one scene), lifecycle (walk the board through every scene), notes (notes lock contention), health (survey answer bursts), quadrant (card positioning and consensus), scorecard (data collection and result flagging), timer (countdowns voted to extend), present (present mode card selection) or voting (vote allocation and exhaustion)")
+	flag.IntVar(&config.NotesCards, "notes-cards", 1, "Cards users compete to edit with -workload notes")
+	flag.DurationVar(&config.NotesHold, "notes-hold", 2*time.Second, "How long a user holds a notes lock while editing")
+	flag.StringVar(&config.HealthPreset, "health-preset", "gallup-q12", "Question preset for -workload health (gallup-q12, standout-q8, spotify-squad, atlassian-team)")
+	flag.IntVar(&config.HealthRounds, "health-rounds", 3, "Synchronized answer bursts with -workload health")
+	flag.IntVar(&config.QuadrantCards, "quadrant-cards", 10, "Cards placed on the quadrant with -workload quadrant")
+	flag.DurationVar(&config.QuadrantInput, "quadrant-input", 30*time.Second, "Length of each quadrant input phase before consensus is calculated")
+	flag.IntVar(&config.Scorecards, "scorecards", 2, "Scorecards attached to the scene with -workload scorecard")
+	flag.DurationVar(&config.ScorecardInterval, "scorecard-interval", 15*time.Second, "Time between scorecard data collections")
+	flag.DurationVar(&config.TimerDuration, "timer-duration", time.Minute, "Length of each countdown with -workload timer, before any extension")
+	flag.DurationVar(&config.TimerTolerance, "timer-tolerance", 2*time.Second, "Allowed gap between a client's countdown and the median client's")
+	flag.IntVar(&config.PresentCards, "present-cards", 10, "Cards the facilitator steps through with -workload present")
+	flag.DurationVar(&config.PresentStep, "present-step", 500*time.Millisecond, "Time between card selections with -workload present")
+	flag.IntVar(&config.VotingCards, "voting-cards", 5, "Cards users spend their votes on with -workload voting")
+	flag.DurationVar(&config.VotingRound, "voting-round", time.Minute, "Length of each voting round with -workload voting; the allocation goes up by one at half time and votes are cleared at the end")
+	flag.IntVar(&config.VotingAllocation, "voting-allocation", 3, "Votes per user at the start of each voting round (1-20)")
+	flag.DurationVar(&config.ChurnInterval, "churn-interval", 0, "Time between board admin churn operations (adding, renaming, reordering and deleting columns, creating and reordering scenes); 0 disables churn")
+	flag.DurationVar(&config.PresencePoll, "presence-poll", 15*time.Second, "Time between checks of the board's presence list against the users connected; 0 disables polling")
+	flag.DurationVar(&config.ConvergeInterval, "converge-interval", 0, "Time between checks of every client's copy of the board against the server's; 0 checks only at the end of the run")
+	flag.IntVar(&config.Boards, "boards", 1, "Boards to spread users across, checking that no event or presence list leaks from one to another (random workload only)")
+	flag.IntVar(&config.Series, "series", 0, "Series the boards are dealt across in turn (default: one series per board)")
+	boardUsersSpec := flag.String("board-users", "", "Users per board as a range, e.g. 5-12; each board's size is drawn from it and -users becomes their total (needs -boards)")
+	flag.DurationVar(&config.SceneDuration, "scene-duration", 0, "Time spent in each scene with -workload lifecycle (default: test duration divided by scene count)")
+	thinkSpec := flag.String("think", "exponential:20s", "Think time between a user's actions with -pacing think, e.g. fixed:10s, uniform:5s-30s, exponential:20s, lognormal:15s:0.8")
+	stagesSpec := flag.String("stages", "", "Load profile as duration:users stages, e.g. 2m:50,10m:50,0s:150,2m:0 (overrides -users and -duration)")
 	flag.Parse()
 
+	config.SceneFlags = DefaultSceneFlags()
+	config.SettleDelay = 2 * time.Second
+	config.WarmupDelay = 3 * time.Second
+	config.SpawnInterval = 100 * time.Millisecond
+
+	// Load scenario file, letting explicitly set flags take precedence
+	if config.ScenarioFile != "" {
+		scenario, err := LoadScenario(config.ScenarioFile)
+		if err != nil {
+			log.Fatalf("Failed to load scenario: %v", err)
+		}
+		scenario.Apply(config, explicitFlags())
+	}
+
+	if *stagesSpec != "" {
+		stages, err := ParseStages(*stagesSpec)
+		if err != nil {
+			log.Fatalf("Invalid -stages: %v", err)
+		}
+		config.Stages = stages
+	}
+	if err := ValidatePacing(config.Pacing); err != nil {
+		log.Fatalf("Invalid -pacing: %v", err)
+	}
+	// Open-model pacing schedules arrivals at this rate and shared pacing ticks
+	// a rate limiter at it; think pacing never reads it
+	if config.Pacing != PacingThink && config.RequestsPerMin < 1 {
+		log.Fatalf("Invalid -rpm: need at least 1 request per minute with -pacing %s, got %d", config.Pacing, config.RequestsPerMin)
+	}
+	if err := ValidateWorkload(config.Workload); err != nil {
+		log.Fatalf("Invalid -workload: %v", err)
+	}
+	// A hold past the server's lock timeout lets the lock pass to another user mid-edit
+	if config.NotesHold >= notesLockTimeout {
+		log.Fatalf("Invalid -notes-hold: must be under the server's %v lock timeout, got %v", notesLockTimeout, config.NotesHold)
+	}
+	// The board settings endpoint caps the allocation at 20
+	if config.Workload == WorkloadVoting && (config.VotingAllocation < 1 || config.VotingAllocation > 20) {
+		log.Fatalf("Invalid -voting-allocation: must be between 1 and 20, got %d", config.VotingAllocation)
+	}
+	if config.Boards < 1 {
+		log.Fatalf("Invalid -boards: need at least one board, got %d", config.Boards)
+	}
+	// Workloads drive one board's meeting, so the other boards would sit idle or half set up
+	if config.Boards > 1 && config.Workload != WorkloadRandom {
+		log.Fatalf("Invalid -boards: %d boards need -workload %s, not %s", config.Boards, WorkloadRandom, config.Workload)
+	}
+	if config.Series < 0 {
+		log.Fatalf("Invalid -series: need 0 or more series, got %d", config.Series)
+	}
+	if *boardUsersSpec != "" {
+		low, high, err := ParseBoardUsers(*boardUsersSpec)
+		if err != nil {
+			log.Fatalf("Invalid -board-users: %v", err)
+		}
+		config.BoardUsersMin, config.BoardUsersMax = low, high
+	}
+	if config.BoardUsersMin > 0 {
+		if config.Boards < 2 {
+			log.Fatalf("Invalid -board-users: needs -boards above 1")
+		}
+		config.BoardSizes = SampleBoardSizes(config.Boards, config.BoardUsersMin, config.BoardUsersMax)
+		// Stages set their own user counts; otherwise the boards are filled exactly
+		if len(config.Stages) == 0 {
+			config.ConcurrentUsers = 0
+			for _, size := range config.BoardSizes {
+				config.ConcurrentUsers += size
+			}
+		}
+	}
+	if config.ThinkTime.Distribution == "" || isFlagSet("think") {
+		thinkTime, err := ParseThinkTime(*thinkSpec)
+		if err != nil {
+			log.Fatalf("Invalid -think: %v", err)
+		}
+		config.ThinkTime = thinkTime
+	}
+	// Unless told otherwise, everyone in a workload takes its signature action
+	if action, ok := workloadActions[config.Workload]; ok && len(config.ActionWeights) == 0 {
+		config.ActionWeights = map[string]int{action: 1}
+	}
+	if len(config.Personas) == 0 {
+		config.Personas = DefaultPersonas(config)
+	}
+	if err := ValidatePersonas(config.Personas); err != nil {
+		log.Fatalf("Invalid personas: %v", err)
+	}
+	if len(config.Stages) > 0 {
+		profile := NewLoadProfile(config)
+		config.TestDuration = profile.TotalDuration()
+		config.ConcurrentUsers = profile.Peak()
+	}
+
 	// Generate unique admin credentials using timestamp
 	timestamp := time.Now().Unix()
 	if config.AdminEmail == "" {
@@ -42,11 +163,36 @@ func main() {
 	}
 }
 
+// explicitFlags returns the names of the flags set on the command line
+func explicitFlags() map[string]bool {
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+	return explicit
+}
+
+// isFlagSet reports whether a flag was set on the command line
+func isFlagSet(name string) bool {
+	return explicitFlags()[name]
+}
+
+// DefaultSceneFlags returns the scene flags forced on the current scene so every action is permitted
+func DefaultSceneFlags() []string {
+	return []string{
+		"allow_add_cards",
+		"allow_edit_cards",
+		"allow_move_cards",
+		"allow_group_cards",
+		"allow_voting",
+		"show_votes",
+		"allow_comments",
+		"show_comments",
+	}
+}
+
 func runLoadTest(config *Config) error {
 	correlator := NewEventCorrelator(config.Verbose)
-	var users []*UserSimulator
-	var connectedUsers int
-	var failedConnections int
 
 	// Setup signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -54,8 +200,8 @@ func runLoadTest(config *Config) error {
 
 	// Setup admin and board
 	// Small delay to avoid hitting rate limits from previous test runs
-	fmt.Println("\n⏳ Waiting 2 seconds to avoid rate limits...")
-	time.Sleep(2 * time.Second)
+	fmt.Printf("\n⏳ Waiting %v to avoid rate limits...\n", config.SettleDelay)
+	time.Sleep(config.SettleDelay)
 
 	PrintSetupProgress("⚙", "Creating admin account")
 	adminAPI := NewAPIClient(config.BaseURL, config.Debug)
@@ -65,16 +211,8 @@ func runLoadTest(config *Config) error {
 			fmt.Printf("Registration failed: %v\n", err)
 		}
 		// Check for rate limit errors
-		if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "Too many requests") {
-			fmt.Println("\n" + strings.Repeat("═", 70))
-			fmt.Println("⚠️  RATE LIMIT DETECTED")
-			fmt.Println(strings.Repeat("═", 70))
-			fmt.Println("\nThe server is rate limiting requests. To run load tests, you need to")
-			fmt.Println("disable rate limiting by setting an environment variable:")
-			fmt.Println("\n  DISABLE_RATE_LIMITING=true npm run dev")
-			fmt.Println("\nOr if running in production mode:")
-			fmt.Println("\n  DISABLE_RATE_LIMITING=true node build")
-			fmt.Println("\n" + strings.Repeat("═", 70))
+		if isRateLimited(err) {
+			PrintRateLimitHelp("RATE LIMIT DETECTED")
 			return fmt.Errorf("rate limit detected - set DISABLE_RATE_LIMITING=true on the server")
 		}
 		return fmt.Errorf("admin registration failed: %w", err)
@@ -102,205 +240,358 @@ func runLoadTest(config *Config) error {
 	}
 
 	PrintSetupProgress("✓", "Creating test board")
-	boardName := fmt.Sprintf("Load Test Board %s", timestamp)
-	board, err := adminAPI.CreateBoard(boardName, series.ID)
+	board, columnIDs, err := setupBoard(adminAPI, config, fmt.Sprintf("Load Test Board %s", timestamp), series.ID)
 	if err != nil {
-		return fmt.Errorf("failed to create board: %w", err)
+		return err
 	}
 	boardID := board.ID
 
-	PrintSetupProgress("✓", "Setting up board template")
-	if err := adminAPI.SetupBoardTemplate(boardID, "basic"); err != nil {
-		return fmt.Errorf("failed to setup board template: %w", err)
-	}
+	PrintSetupProgress("✓", fmt.Sprintf("Found %d columns", len(columnIDs)))
 
-	PrintSetupProgress("✓", "Activating board")
-	if err := adminAPI.UpdateBoard(boardID, map[string]interface{}{"status": "active"}); err != nil {
-		PrintWarning("Setup", "Failed to activate board, continuing anyway")
-	}
+	// The lifecycle workload plays the template's scenes as they are; every
+	// other workload forces the configured flags onto the current scene
+	var meeting *MeetingState
+	if config.Workload == WorkloadLifecycle {
+		if len(board.Scenes) == 0 {
+			return fmt.Errorf("lifecycle workload needs a template with scenes")
+		}
+		meeting = currentSceneMeeting(board)
 
-	// Get board state with columns
-	board, err = adminAPI.GetBoard(boardID)
-	if err != nil {
-		return fmt.Errorf("failed to get board state: %w", err)
+		// Either split the test across the scenes or stretch it to fit them
+		if config.SceneDuration == 0 {
+			config.SceneDuration = config.TestDuration / time.Duration(len(board.Scenes))
+		} else if len(config.Stages) == 0 {
+			config.TestDuration = config.SceneDuration * time.Duration(len(board.Scenes))
+		}
+		PrintSetupProgress("ℹ", fmt.Sprintf("Lifecycle: %d scenes, %v each (test duration %v)",
+			len(board.Scenes), config.SceneDuration, config.TestDuration))
+	} else {
+		PrintSetupProgress("⚙", "Ensuring configured scene permissions are enabled for testing")
+		var currentScene *Scene
+		board, currentScene, err = enableSceneFlags(adminAPI, config, board)
+		if err != nil {
+			return err
+		}
+		if currentScene != nil {
+			PrintSetupProgress("✓", fmt.Sprintf("Scene permissions verified on %s (%s)", currentScene.Title, currentScene.Mode))
+		}
 	}
 
-	var columnIDs []string
-	for _, col := range board.Columns {
-		columnIDs = append(columnIDs, col.ID)
+	// The notes workload concentrates every edit on a few shared cards
+	var notesCards []string
+	if config.Workload == WorkloadNotes {
+		for i := 1; i <= config.NotesCards; i++ {
+			card, err := adminAPI.CreateCard(boardID, columnIDs[0], fmt.Sprintf("Contended notes card %d", i))
+			if err != nil {
+				return fmt.Errorf("failed to create notes card: %w", err)
+			}
+			notesCards = append(notesCards, card.ID)
+		}
+		PrintSetupProgress("✓", fmt.Sprintf("Created %d contended notes cards", len(notesCards)))
 	}
 
-	if len(columnIDs) == 0 {
-		return fmt.Errorf("no columns found in board")
+	// The health workload adds a survey scene to switch to once users are connected
+	var surveyScene Scene
+	if config.Workload == WorkloadHealth {
+		scene, err := adminAPI.CreateScene(boardID, "Health Check", "survey", nil)
+		if err != nil {
+			return fmt.Errorf("failed to create survey scene: %w", err)
+		}
+		surveyScene = *scene
+		meeting = currentSceneMeeting(board)
+		PrintSetupProgress("✓", "Created survey scene")
 	}
 
-	PrintSetupProgress("✓", fmt.Sprintf("Found %d columns", len(columnIDs)))
-
-	// Update scene permissions for testing - always force all permissions
-	if board.CurrentSceneID != "" {
-		var currentScene *Scene
-		for _, scene := range board.Scenes {
-			if scene.ID == board.CurrentSceneID {
-				currentScene = &scene
-				break
-			}
+	// The quadrant workload adds a configured quadrant scene and the cards to place on it
+	var quadrantScene Scene
+	var quadrantCards []string
+	if config.Workload == WorkloadQuadrant {
+		scene, err := adminAPI.CreateScene(boardID, "Quadrant", "quadrant", nil)
+		if err != nil {
+			return fmt.Errorf("failed to create quadrant scene: %w", err)
 		}
-
-		if currentScene != nil {
-			PrintSetupProgress("ℹ", fmt.Sprintf("Current scene: %s (%s)", currentScene.Title, currentScene.Mode))
-			PrintSetupProgress("⚙", "Ensuring all scene permissions enabled for testing")
-
-			// Scene permissions are stored as flags in an array
-			flags := []string{
-				"allow_add_cards",
-				"allow_edit_cards",
-				"allow_move_cards",
-				"allow_group_cards",
-				"allow_voting",
-				"show_votes",
-				"allow_comments",
-				"show_comments",
-			}
-
-			err := adminAPI.UpdateScene(boardID, currentScene.ID, map[string]interface{}{
-				"flags": flags,
-			})
+		// Consensus needs the axes, which the server stores as a JSON string
+		if err := adminAPI.UpdateScene(boardID, scene.ID, map[string]interface{}{
+			"quadrantConfig": quadrantConfigString,
+		}); err != nil {
+			return fmt.Errorf("failed to configure quadrant scene: %w", err)
+		}
+		quadrantScene = *scene
+		for i := 1; i <= config.QuadrantCards; i++ {
+			card, err := adminAPI.CreateCard(boardID, columnIDs[i%len(columnIDs)], fmt.Sprintf("Quadrant card %d", i))
 			if err != nil {
-				return fmt.Errorf("failed to update scene permissions: %w", err)
+				return fmt.Errorf("failed to create quadrant card: %w", err)
 			}
+			quadrantCards = append(quadrantCards, card.ID)
+		}
+		if len(quadrantCards) == 0 {
+			return fmt.Errorf("quadrant workload needs at least one card")
+		}
+		meeting = currentSceneMeeting(board)
+		PrintSetupProgress("✓", fmt.Sprintf("Created quadrant scene with %d cards", len(quadrantCards)))
+	}
 
-			PrintSetupProgress("✓", "Scene permissions enabled")
+	// The scorecard workload adds a scorecard scene and the scorecards to attach to it
+	var scorecardScene Scene
+	var scorecardSources []scorecardSource
+	if config.Workload == WorkloadScorecard {
+		scene, err := adminAPI.CreateScene(boardID, "Scorecard", "scorecard", nil)
+		if err != nil {
+			return fmt.Errorf("failed to create scorecard scene: %w", err)
+		}
+		scorecardScene = *scene
+		scorecardSources, err = SetupScorecards(adminAPI, series.ID, config.Scorecards)
+		if err != nil {
+			return fmt.Errorf("failed to create scorecards: %w", err)
+		}
+		if len(scorecardSources) == 0 {
+			return fmt.Errorf("scorecard workload needs at least one scorecard")
+		}
+		meeting = currentSceneMeeting(board)
+		PrintSetupProgress("✓", fmt.Sprintf("Created scorecard scene and %d scorecards", len(scorecardSources)))
+	}
 
-			// Verify permissions were set by re-fetching board
-			board, err = adminAPI.GetBoard(boardID)
+	// The present workload adds a present mode scene and the cards to step through
+	var presentScene Scene
+	var presentCards []string
+	if config.Workload == WorkloadPresent {
+		scene, err := adminAPI.CreateScene(boardID, "Present", "present", nil)
+		if err != nil {
+			return fmt.Errorf("failed to create present scene: %w", err)
+		}
+		presentScene = *scene
+		for i := 1; i <= config.PresentCards; i++ {
+			card, err := adminAPI.CreateCard(boardID, columnIDs[i%len(columnIDs)], fmt.Sprintf("Presented card %d", i))
 			if err != nil {
-				return fmt.Errorf("failed to verify board state: %w", err)
-			}
-
-			if config.Verbose {
-				fmt.Printf("DEBUG: Re-fetched board, currentSceneID: %s\n", board.CurrentSceneID)
-				fmt.Printf("DEBUG: Number of scenes: %d\n", len(board.Scenes))
-				for i, scene := range board.Scenes {
-					fmt.Printf("DEBUG: Scene %d: ID=%s, Title=%s, Flags=%v\n",
-						i, scene.ID, scene.Title, scene.Flags)
-				}
+				return fmt.Errorf("failed to create present card: %w", err)
 			}
+			presentCards = append(presentCards, card.ID)
+		}
+		if len(presentCards) == 0 {
+			return fmt.Errorf("present workload needs at least one card")
+		}
+		meeting = currentSceneMeeting(board)
+		PrintSetupProgress("✓", fmt.Sprintf("Created present scene with %d cards", len(presentCards)))
+	}
 
-			// Verify the scene has the required flags
-			for _, scene := range board.Scenes {
-				if scene.ID == board.CurrentSceneID {
-					hasAddCards := scene.HasFlag("allow_add_cards")
-					hasMoveCards := scene.HasFlag("allow_move_cards")
-					hasGroupCards := scene.HasFlag("allow_group_cards")
-					hasVoting := scene.HasFlag("allow_voting")
-
-					if !hasAddCards || !hasMoveCards || !hasGroupCards || !hasVoting {
-						return fmt.Errorf("scene permissions not properly set: add=%v move=%v group=%v vote=%v (flags=%v)",
-							hasAddCards, hasMoveCards, hasGroupCards, hasVoting, scene.Flags)
-					}
-					PrintSetupProgress("✓", "Scene permissions verified")
-					break
-				}
+	// The voting workload adds the cards users spend their votes on
+	var votingCards []string
+	if config.Workload == WorkloadVoting {
+		for i := 1; i <= config.VotingCards; i++ {
+			card, err := adminAPI.CreateCard(boardID, columnIDs[i%len(columnIDs)], fmt.Sprintf("Voting card %d", i))
+			if err != nil {
+				return fmt.Errorf("failed to create voting card: %w", err)
 			}
-		} else {
-			PrintWarning("Setup", "No current scene found - permissions may be restricted")
+			votingCards = append(votingCards, card.ID)
 		}
-	} else {
-		PrintWarning("Setup", "No current scene ID - permissions may be restricted")
+		if len(votingCards) == 0 {
+			return fmt.Errorf("voting workload needs at least one card")
+		}
+		PrintSetupProgress("✓", fmt.Sprintf("Created %d voting cards", len(votingCards)))
+	}
+
+	// The other boards share out the series, by default one each, so users can only reach their own board
+	neighbours, err := setupNeighbourBoards(adminAPI, config, timestamp, series.ID)
+	if err != nil {
+		return err
 	}
 
 	// Print board URL
 	PrintBoardURL(config.BaseURL, boardID)
-	fmt.Println("⏳ Starting user connections in 3 seconds...\n")
-	time.Sleep(3 * time.Second)
-
-	// Create global rate limiter (requests per minute across all users)
-	requestInterval := time.Duration(60.0/float64(config.RequestsPerMin)*1000) * time.Millisecond
-	rateLimiter := time.NewTicker(requestInterval)
-	defer rateLimiter.Stop()
-
-	// Spawn users
-	fmt.Printf("\nSpawning %d users...\n", config.ConcurrentUsers)
+	fmt.Printf("⏳ Starting user connections in %v...\n\n", config.WarmupDelay)
+	time.Sleep(config.WarmupDelay)
+
+	// Create global rate limiter (requests per minute across all users).
+	// Open-model pacing leaves it nil and dispatches through an ArrivalScheduler;
+	// think pacing leaves it nil too, as every user paces itself.
+	var rateLimiterC <-chan time.Time
+	if config.Pacing == PacingShared {
+		requestInterval := time.Duration(60.0/float64(config.RequestsPerMin)*1000) * time.Millisecond
+		rateLimiter := time.NewTicker(requestInterval)
+		defer rateLimiter.Stop()
+		rateLimiterC = rateLimiter.C
+	}
 
 	var wg sync.WaitGroup
 	stopChan := make(chan bool)
+	pool := NewUserPool()
+	timings := NewActionTimings()
+	var quadrant *QuadrantSession
+	if config.Workload == WorkloadQuadrant {
+		quadrant = NewQuadrantSession(adminAPI, boardID, quadrantScene, quadrantCards, config.QuadrantInput, meeting, correlator)
+	}
+	var scorecards *ScorecardSession
+	if config.Workload == WorkloadScorecard {
+		scorecards = NewScorecardSession(adminAPI, boardID, scorecardScene, scorecardSources, config.ScorecardInterval, meeting, correlator)
+	}
+	var timers *TimerSession
+	if config.Workload == WorkloadTimer {
+		timers = NewTimerSession(adminAPI, boardID, config.TimerDuration, config.TimerTolerance, pool, correlator)
+	}
+	var present *PresentSession
+	if config.Workload == WorkloadPresent {
+		present = NewPresentSession(adminAPI, boardID, presentScene, presentCards, config.PresentStep, meeting, pool, correlator)
+	}
+	var voting *VotingSession
+	if config.Workload == WorkloadVoting {
+		voting = NewVotingSession(adminAPI, boardID, votingCards, config.VotingAllocation, config.VotingRound, correlator)
+	}
+	var churn *ChurnDriver
+	if config.ChurnInterval > 0 {
+		churn = NewChurnDriver(adminAPI, boardID, config.ChurnInterval, correlator)
+	}
+	run := &RunContext{
+		Config:      config,
+		Correlator:  correlator,
+		Timings:     timings,
+		Meeting:     meeting,
+		Notes:       NewNotesContention(notesCards, config.NotesHold),
+		Quadrant:    quadrant,
+		Scorecards:  scorecards,
+		Timers:      timers,
+		Present:     present,
+		Voting:      voting,
+		Clones:      NewCloneTracker(correlator),
+		Presence:    NewPresenceTracker(adminAPI, boardID, config.PresencePoll),
+		Convergence: NewConvergenceChecker(adminAPI, boardID, config.ConvergeInterval, pool),
+		Churn:       churn,
+		Schema:      NewSchemaValidator(),
+		Isolation:   NewIsolationChecker(config.Boards),
+	}
+	correlator.SetUserBoard(0, boardID) // Drivers act on the first board
+	boards := []*BoardTarget{{ID: boardID, SeriesID: series.ID, ColumnIDs: columnIDs, Size: boardSize(config, 0), Run: run}}
+	for _, neighbour := range neighbours {
+		neighbour.Run = run.neighbour()
+		boards = append(boards, neighbour)
+	}
+	spawner := NewUserSpawner(pool, adminAPI, boards, rateLimiterC, stopChan, &wg)
+	profile := NewLoadProfile(config)
+	staged := len(config.Stages) > 0
+
+	// Without stages every user is spawned up front, before the test clock starts
+	if !staged {
+		fmt.Printf("\nSpawning %d users...\n", config.ConcurrentUsers)
 
-	for i := 1; i <= config.ConcurrentUsers; i++ {
-		user := NewUserSimulator(i, boardID, columnIDs, correlator, config)
-
-		if err := user.Setup(); err != nil {
-			// Check for rate limit errors
-			if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "Too many requests") {
-				fmt.Println("\n" + strings.Repeat("═", 70))
-				fmt.Println("⚠️  RATE LIMIT DETECTED DURING USER SPAWNING")
-				fmt.Println(strings.Repeat("═", 70))
-				fmt.Println("\nThe server is rate limiting requests. To run load tests, you need to")
-				fmt.Println("disable rate limiting by setting an environment variable:")
-				fmt.Println("\n  DISABLE_RATE_LIMITING=true npm run dev")
-				fmt.Println("\nOr if running in production mode:")
-				fmt.Println("\n  DISABLE_RATE_LIMITING=true node build")
-				fmt.Println("\n" + strings.Repeat("═", 70))
-				return fmt.Errorf("rate limit detected - set DISABLE_RATE_LIMITING=true on the server")
+		for i := 1; i <= config.ConcurrentUsers; i++ {
+			if err := spawner.SpawnOne(config.ConcurrentUsers); err != nil {
+				return err
 			}
-			PrintError("Spawn", fmt.Sprintf("User %d setup failed: %v", i, err))
-			failedConnections++
-			continue
+
+			// Stagger connections
+			time.Sleep(config.SpawnInterval)
 		}
 
-		// Add user to series (admin API call required)
-		if err := adminAPI.AddUserToSeries(series.ID, user.ctx.Email, "member"); err != nil {
-			PrintError("Spawn", fmt.Sprintf("User %d add to series failed: %v", i, err))
-			failedConnections++
-			user.Stop()
-			continue
+		_, connected, failed := spawner.Counts()
+		fmt.Printf("\n✓ Connected %d/%d users\n", connected, config.ConcurrentUsers)
+		if failed > 0 {
+			fmt.Printf("✗ %d connection failures\n", failed)
 		}
+	} else {
+		fmt.Printf("\nRunning load profile: %s\n", FormatStages(config.Stages))
+	}
 
-		users = append(users, user)
-		connectedUsers++
+	// Start monitoring
+	fmt.Print("\n🔍 Starting monitoring...\n\n")
 
-		// Update correlator with current connected user count
-		correlator.SetConnectedUsers(connectedUsers)
+	// Track actual test start time (after all setup is complete)
+	testStartTime := time.Now()
 
-		// Start user activity in background
-		wg.Add(1)
-		go func(u *UserSimulator) {
-			defer wg.Done()
-			u.Start(stopChan, rateLimiter.C)
-		}(user)
+	// Staged runs grow and shrink the user pool while the test runs
+	profileErr := make(chan error, 1)
+	profileStop := make(chan bool)
+	profileDone := make(chan bool)
+	if staged {
+		go func() {
+			defer close(profileDone)
+			if err := RunLoadProfile(profile, spawner, testStartTime, profileStop); err != nil {
+				profileErr <- err
+			}
+		}()
+	} else {
+		close(profileDone)
+	}
 
-		PrintSuccess("Spawn", fmt.Sprintf("User %d/%d connected", i, config.ConcurrentUsers))
+	// Every driver below runs alongside the users until stopChan closes
+	var drivers sync.WaitGroup
+	runDriver := func(driver func(stopChan <-chan bool)) {
+		drivers.Add(1)
+		go func() {
+			defer drivers.Done()
+			driver(stopChan)
+		}()
+	}
 
-		// Stagger connections
-		time.Sleep(100 * time.Millisecond)
+	// Open-model pacing schedules arrivals independently of response times
+	if IsOpenModel(config.Pacing) {
+		runDriver(NewArrivalScheduler(pool, config.Pacing, config.RequestsPerMin).Run)
 	}
 
-	fmt.Printf("\n✓ Connected %d/%d users\n", connectedUsers, config.ConcurrentUsers)
-	if failedConnections > 0 {
-		fmt.Printf("✗ %d connection failures\n", failedConnections)
+	// The lifecycle workload changes scene on its own schedule
+	var lifecycle *LifecycleDriver
+	if config.Workload == WorkloadLifecycle {
+		lifecycle = NewLifecycleDriver(adminAPI, boardID, board.Scenes, config.SceneDuration, meeting, correlator)
+		runDriver(lifecycle.Run)
 	}
 
-	// Start monitoring
-	fmt.Println("\n🔍 Starting monitoring...\n")
+	// The health workload switches to the survey and runs its answer bursts
+	var survey *HealthSurvey
+	if config.Workload == WorkloadHealth {
+		survey = NewHealthSurvey(adminAPI, boardID, surveyScene, config, pool, meeting, correlator)
+		runDriver(survey.Run)
+	}
 
-	// Track actual test start time (after all setup is complete)
-	testStartTime := time.Now()
+	// The quadrant workload cycles through input, consensus and reset
+	if quadrant != nil {
+		runDriver(quadrant.Run)
+	}
+
+	// The scorecard workload attaches scorecards and re-collects their data
+	if scorecards != nil {
+		runDriver(scorecards.Run)
+	}
+
+	// The timer workload starts, extends and stops countdowns
+	if timers != nil {
+		runDriver(timers.Run)
+	}
+
+	// The present workload steps through the presented cards
+	if present != nil {
+		runDriver(present.Run)
+	}
+
+	// The voting workload opens, checks and clears voting rounds
+	if voting != nil {
+		runDriver(voting.Run)
+	}
+
+	// Board admin churn reconfigures columns and scenes alongside any workload
+	if churn != nil {
+		runDriver(churn.Run)
+	}
+
+	// Poll the board's presence list while users come and go
+	runDriver(run.Presence.Run)
+
+	// Check client board copies at checkpoints while the run goes on
+	runDriver(run.Convergence.Run)
 
 	monitorTicker := time.NewTicker(10 * time.Second)
 	defer monitorTicker.Stop()
 
-	testTimer := time.NewTimer(config.TestDuration)
+	testTimer := time.NewTimer(profile.TotalDuration())
 	defer testTimer.Stop()
 
-	monitoringActive := true
+	monitorDone := make(chan bool)
 
 	// Monitoring loop
 	go func() {
-		for monitoringActive {
+		for {
 			select {
 			case <-monitorTicker.C:
 				elapsed := time.Since(testStartTime)
 				activeUsers := 0
-				for _, u := range users {
+				for _, u := range pool.Users() {
 					if u.IsConnected() {
 						activeUsers++
 					}
@@ -309,56 +600,159 @@ func runLoadTest(config *Config) error {
 				sent, received := correlator.GetStats()
 				rate := float64(sent) / elapsed.Seconds()
 
-				PrintMonitoringStats(elapsed, activeUsers, connectedUsers, sent, received, rate)
+				PrintMonitoringStats(elapsed, activeUsers, profile.TargetAt(elapsed), sent, received, rate)
 
-			case <-testTimer.C:
-				return
-			case <-sigChan:
+			case <-monitorDone:
 				return
 			}
 		}
 	}()
 
-	// Wait for test duration or interrupt
+	// Wait for test duration, interrupt or a fatal spawning error
+	var runErr error
 	select {
 	case <-testTimer.C:
 		fmt.Println("\n⏱ Test duration completed")
 	case <-sigChan:
 		fmt.Println("\n\n⏹ Interrupted by user")
+	case runErr = <-profileErr:
 	}
 
-	monitoringActive = false
+	close(monitorDone)
 
 	// Capture test end time (before grace period)
 	testEndTime := time.Now()
 
+	// Stop changing the user population before tearing down
+	close(profileStop)
+	<-profileDone
+
 	// Stop all users
 	fmt.Println("\n[Cleanup] Stopping event generation...")
 	close(stopChan)
+	drivers.Wait()
+
+	if runErr != nil {
+		spawner.StopAll()
+		wg.Wait()
+		return runErr
+	}
 
 	// Grace period
 	fmt.Printf("[Cleanup] Grace period: waiting %v for pending events...\n", config.GracePeriod)
 	time.Sleep(config.GracePeriod)
 
+	// Every event has had its chance to land, so every client should now agree with the board
+	fmt.Println("[Cleanup] Checking client boards against the server...")
+	run.Convergence.Final()
+
+	// Users leaving from here on are teardown, not something to check
+	run.Presence.Stop()
+
 	// Disconnect all users
 	fmt.Println("[Cleanup] Disconnecting users...")
-	for _, user := range users {
-		user.Stop()
-	}
+	peakUsers := pool.Peak()
+	spawner.StopAll()
 
 	// Wait for all goroutines to finish
 	wg.Wait()
 
 	// Generate and print final report
-	result := correlator.GenerateReport(connectedUsers)
-	result.ConnectedUsers = connectedUsers
-	result.TotalUsers = config.ConcurrentUsers
+	spawned, connected, failed := spawner.Counts()
+	result := correlator.GenerateReport(connected)
+	result.ConnectedUsers = connected
+	result.TotalUsers = spawned
+	result.PeakUsers = peakUsers
 	result.Duration = testEndTime.Sub(testStartTime) // Actual test duration excluding setup and grace period
 	result.ConnectionStability = &ConnectionStats{
-		FailedConns: failedConnections,
+		FailedConns: failed,
+	}
+	if staged {
+		result.Stages = config.Stages
+	}
+	result.Pacing = config.Pacing
+	result.ActionTimings = timings.Stats()
+	if lifecycle != nil {
+		result.Scenes = lifecycle.Report()
+	}
+	if survey != nil {
+		result.Health = survey.Report()
+	}
+	if quadrant != nil {
+		result.Quadrant = quadrant.Report()
+	}
+	if scorecards != nil {
+		result.Scorecards = scorecards.Report()
+	}
+	if timers != nil {
+		result.Timers = timers.Report()
+	}
+	if present != nil {
+		result.Present = present.Report()
+	}
+	if voting != nil {
+		result.Voting = voting.Report()
+	}
+	if churn != nil {
+		result.Churn = churn.Report()
+	}
+	result.Clones = run.Clones.Report()
+	result.Presence = run.Presence.Report()
+	result.Convergence = run.Convergence.Report()
+	result.Schema = run.Schema.Report()
+	result.Isolation = run.Isolation.Report()
+	if len(boards) > 1 {
+		result.Fleet = NewFleetReport(boards, seriesCount(config), result.ByBoard, spawner.ConnectedByBoard())
+	}
+	if notes := run.Notes.Stats(); notes.Attempts > 0 {
+		result.NotesLocks = notes
+	}
+	for _, user := range spawner.AllUsers() {
+		actions, failures := user.ctx.ActionCounts()
+		result.PerUser = append(result.PerUser, UserActivity{
+			UserID:   user.GetID(),
+			Persona:  user.Persona().Name,
+			Actions:  actions,
+			Failures: failures,
+		})
+
+		persona, ok := result.ByPersona[user.Persona().Name]
+		if !ok {
+			persona = &PersonaStats{}
+			result.ByPersona[user.Persona().Name] = persona
+		}
+		persona.Users++
+		persona.Actions += actions
+		persona.Failures += failures
 	}
 
 	PrintFinalReport(result, config)
 
 	return nil
 }
+
+// currentSceneMeeting tracks the meeting from the board's current scene
+func currentSceneMeeting(board *BoardState) *MeetingState {
+	meeting := NewMeetingState(Scene{})
+	for _, scene := range board.Scenes {
+		if scene.ID == board.CurrentSceneID {
+			meeting.SetScene(scene)
+		}
+	}
+	return meeting
+}
+
+// waitOrStop sleeps for d, reporting whether stopChan closed first
+func waitOrStop(d time.Duration, stopChan <-chan bool) bool {
+	select {
+	case <-stopChan:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+
+// isRateLimited reports whether an API error came from server-side rate limiting
+func isRateLimited(err error) bool {
+	return strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "Too many requests")
+}
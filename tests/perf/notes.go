@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Retry policy for a user waiting on a notes lock held by someone else
+const (
+	notesMaxAttempts  = 5
+	notesRetryBackoff = 250 * time.Millisecond
+)
+
+// notesLockTimeout matches LOCK_TIMEOUT in the server's notes-lock, after
+// which a held lock expires and may be granted to someone else
+const notesLockTimeout = 5 * time.Minute
+
+// NotesContention tracks users competing for the edit lock on card notes.
+//
+// Each user's view of holding a lock runs from its acquire returning until it
+// sends the save, which always lies inside the server's own lock period. Two
+// users inside that window on the same card at once is therefore a real
+// double grant, not a timing artefact.
+type NotesContention struct {
+	cardIDs          []string
+	hold             time.Duration
+	holders          map[string]int // cardID -> user that believes it holds the lock
+	requestLatencies []time.Duration
+	acquireWaits     []time.Duration
+	attempts         int
+	rejections       int
+	acquired         int
+	abandoned        int
+	failures         int
+	overlaps         int
+	interleaved      int
+	mu               sync.Mutex
+}
+
+// NewNotesContention creates a tracker for contention on cardIDs. With no
+// cards users pick a random card from the board instead.
+func NewNotesContention(cardIDs []string, hold time.Duration) *NotesContention {
+	return &NotesContention{
+		cardIDs: cardIDs,
+		hold:    hold,
+		holders: make(map[string]int),
+	}
+}
+
+// PickCard returns one of the contended cards, or "" if there are none
+func (n *NotesContention) PickCard() string {
+	if len(n.cardIDs) == 0 {
+		return ""
+	}
+	return n.cardIDs[rand.Intn(len(n.cardIDs))]
+}
+
+// Hold returns how long a user keeps the lock while editing
+func (n *NotesContention) Hold() time.Duration {
+	return n.hold
+}
+
+// RecordAttempt stores one lock request
+func (n *NotesContention) RecordAttempt(latency time.Duration, acquired bool, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.attempts++
+	switch {
+	case err != nil:
+		n.failures++
+	case acquired:
+		n.requestLatencies = append(n.requestLatencies, latency)
+	default:
+		n.rejections++
+	}
+}
+
+// RecordAcquired notes that userID now holds the lock on cardID after
+// waiting wait since its first attempt, flagging a double grant
+func (n *NotesContention) RecordAcquired(cardID string, userID int, wait time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.acquired++
+	n.acquireWaits = append(n.acquireWaits, wait)
+	if holder, ok := n.holders[cardID]; ok && holder != userID {
+		n.overlaps++
+		PrintError("Notes", fmt.Sprintf("Lock on card %s granted to user %d while user %d still held it", cardID, userID, holder))
+	}
+	n.holders[cardID] = userID
+}
+
+// Release ends userID's hold on cardID
+func (n *NotesContention) Release(cardID string, userID int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.holders[cardID] == userID {
+		delete(n.holders, cardID)
+	}
+}
+
+// RecordAbandoned counts a user giving up after every attempt was rejected
+func (n *NotesContention) RecordAbandoned() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.abandoned++
+}
+
+// RecordInterleaved counts a write by another user landing while the lock was held
+func (n *NotesContention) RecordInterleaved(cardID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.interleaved++
+	PrintError("Notes", fmt.Sprintf("Notes on card %s changed while the lock holder was editing", cardID))
+}
+
+// Stats returns the contention results
+func (n *NotesContention) Stats() *NotesLockStats {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &NotesLockStats{
+		Cards:          len(n.cardIDs),
+		Attempts:       n.attempts,
+		Rejections:     n.rejections,
+		Acquired:       n.acquired,
+		Abandoned:      n.abandoned,
+		Failures:       n.failures,
+		Overlaps:       n.overlaps,
+		Interleaved:    n.interleaved,
+		RequestLatency: computeLatencyStats(n.requestLatencies),
+		AcquireWait:    computeLatencyStats(n.acquireWaits),
+	}
+}
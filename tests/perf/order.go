@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// deliveryOrder follows the card events one client receives, to spot any
+// delivered out of causal order. It is only used from the client's
+// listener, so it needs no lock.
+type deliveryOrder struct {
+	known   map[string]bool      // Cards on the board at connect, or whose card_created arrived
+	deleted map[string]bool      // Cards whose card_deleted arrived
+	early   map[string]string    // card -> first event about it that came before its card_created
+	votes   map[string]voteTotal // card -> last vote total received
+}
+
+// voteTotal is a card's vote total and when the server stamped it
+type voteTotal struct {
+	count   int
+	stamped time.Time
+}
+
+// newDeliveryOrder starts from the board the client loaded on connect
+func newDeliveryOrder(board *BoardState) *deliveryOrder {
+	o := &deliveryOrder{
+		known:   make(map[string]bool, len(board.Cards)),
+		deleted: make(map[string]bool),
+		early:   make(map[string]string),
+		votes:   make(map[string]voteTotal),
+	}
+	for _, card := range board.Cards {
+		o.known[card.ID] = true
+	}
+	return o
+}
+
+// check takes the next event the client received and describes how it
+// broke causal order, or returns "" if it did not. An event about a card
+// the client has not seen created is only reported once the card_created
+// arrives, since until then the create may simply be missing.
+func (o *deliveryOrder) check(event ReceivedEvent) string {
+	if event.VotesCleared {
+		o.votes = make(map[string]voteTotal)
+	}
+
+	switch event.Type {
+	case "card_created":
+		o.known[event.CardID] = true
+		if o.deleted[event.CardID] {
+			return "card_created after card_deleted"
+		}
+		if early, ok := o.early[event.CardID]; ok {
+			delete(o.early, event.CardID)
+			return early + " before card_created"
+		}
+
+	case "card_updated", "vote_changed", "card_deleted":
+		if !o.known[event.CardID] && !o.deleted[event.CardID] {
+			if _, ok := o.early[event.CardID]; !ok {
+				o.early[event.CardID] = event.Type
+			}
+		}
+		if event.Type == "card_deleted" {
+			o.deleted[event.CardID] = true
+			delete(o.votes, event.CardID)
+			return ""
+		}
+		if event.VoteCount == nil {
+			return ""
+		}
+		// Concurrent votes can broadcast a stale, lower total after a higher
+		// one, so a falling total is only out of order if the server also
+		// built it first
+		previous, seen := o.votes[event.CardID]
+		o.votes[event.CardID] = voteTotal{*event.VoteCount, event.Stamped}
+		if seen && *event.VoteCount < previous.count &&
+			!event.Stamped.IsZero() && event.Stamped.Before(previous.stamped) {
+			return fmt.Sprintf("vote total %d after %d, though built before it", *event.VoteCount, previous.count)
+		}
+
+	case "all_votes_updated":
+		if event.AllVotes != nil {
+			o.votes = make(map[string]voteTotal, len(event.AllVotes))
+			for cardID, count := range event.AllVotes {
+				o.votes[cardID] = voteTotal{count, event.Stamped}
+			}
+		}
+	}
+	return ""
+}
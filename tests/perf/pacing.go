@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pacing modes decide when users perform actions
+const (
+	// PacingShared is the closed model: all users share one ticker and a tick
+	// is skipped if nobody is free to take it
+	PacingShared = "shared"
+	// PacingConstant is an open model with evenly spaced arrivals
+	PacingConstant = "constant"
+	// PacingPoisson is an open model with exponentially distributed inter-arrival times
+	PacingPoisson = "poisson"
+	// PacingThink gives every user its own loop: think, act, repeat
+	PacingThink = "think"
+)
+
+// Think-time distributions
+const (
+	ThinkFixed       = "fixed"
+	ThinkUniform     = "uniform"
+	ThinkExponential = "exponential"
+	ThinkLognormal   = "lognormal"
+)
+
+// ValidatePacing checks that mode is a supported pacing mode
+func ValidatePacing(mode string) error {
+	switch mode {
+	case PacingShared, PacingConstant, PacingPoisson, PacingThink:
+		return nil
+	}
+	return fmt.Errorf("unknown pacing %q (use %s, %s, %s or %s)", mode, PacingShared, PacingConstant, PacingPoisson, PacingThink)
+}
+
+// IsOpenModel reports whether actions are scheduled independently of response times
+func IsOpenModel(mode string) bool {
+	return mode == PacingConstant || mode == PacingPoisson
+}
+
+// ThinkTime is the distribution of pauses between one user's actions
+type ThinkTime struct {
+	Distribution string
+	Mean         time.Duration // fixed, exponential and lognormal
+	Min          time.Duration // uniform lower bound; lower clamp otherwise
+	Max          time.Duration // uniform upper bound; upper clamp otherwise
+	Sigma        float64       // lognormal shape (default 0.5)
+}
+
+// ThinkTimeSpec is the scenario file form of a ThinkTime
+type ThinkTimeSpec struct {
+	Distribution string   `json:"distribution" yaml:"distribution"`
+	Mean         Duration `json:"mean" yaml:"mean"`
+	Min          Duration `json:"min" yaml:"min"`
+	Max          Duration `json:"max" yaml:"max"`
+	Sigma        float64  `json:"sigma" yaml:"sigma"`
+}
+
+// ThinkTime converts the spec and validates it
+func (s ThinkTimeSpec) ThinkTime() (ThinkTime, error) {
+	t := ThinkTime{
+		Distribution: s.Distribution,
+		Mean:         time.Duration(s.Mean),
+		Min:          time.Duration(s.Min),
+		Max:          time.Duration(s.Max),
+		Sigma:        s.Sigma,
+	}
+	return t, t.Validate()
+}
+
+// ParseThinkTime parses a think time such as "fixed:10s", "uniform:5s-30s",
+// "exponential:20s", "lognormal:15s" or "lognormal:15s:0.8"
+func ParseThinkTime(spec string) (ThinkTime, error) {
+	parts := strings.Split(spec, ":")
+	t := ThinkTime{Distribution: parts[0]}
+	if len(parts) < 2 {
+		return t, fmt.Errorf("think time %q must be distribution:value", spec)
+	}
+
+	var err error
+	switch t.Distribution {
+	case ThinkUniform:
+		minStr, maxStr, ok := strings.Cut(parts[1], "-")
+		if !ok {
+			return t, fmt.Errorf("uniform think time %q must be uniform:min-max", spec)
+		}
+		if t.Min, err = time.ParseDuration(minStr); err != nil {
+			return t, fmt.Errorf("think time %q: %w", spec, err)
+		}
+		if t.Max, err = time.ParseDuration(maxStr); err != nil {
+			return t, fmt.Errorf("think time %q: %w", spec, err)
+		}
+	default:
+		if t.Mean, err = time.ParseDuration(parts[1]); err != nil {
+			return t, fmt.Errorf("think time %q: %w", spec, err)
+		}
+		if len(parts) > 2 {
+			if t.Sigma, err = strconv.ParseFloat(parts[2], 64); err != nil {
+				return t, fmt.Errorf("think time %q: invalid sigma: %w", spec, err)
+			}
+		}
+	}
+
+	return t, t.Validate()
+}
+
+// Validate checks the distribution name and its parameters
+func (t ThinkTime) Validate() error {
+	switch t.Distribution {
+	case ThinkFixed, ThinkExponential, ThinkLognormal:
+		if t.Mean <= 0 {
+			return fmt.Errorf("%s think time needs a positive mean", t.Distribution)
+		}
+	case ThinkUniform:
+		if t.Min < 0 || t.Max <= t.Min {
+			return fmt.Errorf("uniform think time needs 0 <= min < max")
+		}
+	default:
+		return fmt.Errorf("unknown think time distribution %q (use %s, %s, %s or %s)",
+			t.Distribution, ThinkFixed, ThinkUniform, ThinkExponential, ThinkLognormal)
+	}
+	if t.Sigma < 0 {
+		return fmt.Errorf("think time sigma must not be negative")
+	}
+	return nil
+}
+
+// Sample draws one pause from the distribution
+func (t ThinkTime) Sample() time.Duration {
+	var d time.Duration
+	switch t.Distribution {
+	case ThinkUniform:
+		return t.Min + time.Duration(rand.Int63n(int64(t.Max-t.Min)))
+	case ThinkExponential:
+		d = time.Duration(rand.ExpFloat64() * float64(t.Mean))
+	case ThinkLognormal:
+		sigma := t.sigma()
+		// Choose mu so the distribution's mean equals t.Mean
+		mu := math.Log(float64(t.Mean)) - sigma*sigma/2
+		d = time.Duration(math.Exp(mu + sigma*rand.NormFloat64()))
+	default:
+		d = t.Mean
+	}
+
+	if t.Min > 0 && d < t.Min {
+		d = t.Min
+	}
+	if t.Max > 0 && d > t.Max {
+		d = t.Max
+	}
+	return d
+}
+
+// sigma returns the lognormal shape, applying the default when none was given
+func (t ThinkTime) sigma() float64 {
+	if t.Sigma == 0 {
+		return 0.5
+	}
+	return t.Sigma
+}
+
+// String renders the think time for configuration output
+func (t ThinkTime) String() string {
+	switch t.Distribution {
+	case ThinkUniform:
+		return fmt.Sprintf("uniform %v-%v", t.Min, t.Max)
+	case ThinkLognormal:
+		return fmt.Sprintf("lognormal mean %v sigma %.2f", t.Mean, t.sigma())
+	}
+	return fmt.Sprintf("%s mean %v", t.Distribution, t.Mean)
+}
+
+// ActionTimings records how long actions took relative to when they were
+// meant to start and when they actually started
+type ActionTimings struct {
+	fromIntended []time.Duration
+	fromActual   []time.Duration
+	startDelays  []time.Duration
+	failures     int
+	mu           sync.Mutex
+}
+
+// NewActionTimings creates an empty timing recorder
+func NewActionTimings() *ActionTimings {
+	return &ActionTimings{
+		fromIntended: make([]time.Duration, 0),
+		fromActual:   make([]time.Duration, 0),
+		startDelays:  make([]time.Duration, 0),
+	}
+}
+
+// Record stores one completed action
+func (t *ActionTimings) Record(intended, started, finished time.Time, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fromIntended = append(t.fromIntended, finished.Sub(intended))
+	t.fromActual = append(t.fromActual, finished.Sub(started))
+	t.startDelays = append(t.startDelays, started.Sub(intended))
+	if err != nil {
+		t.failures++
+	}
+}
+
+// Stats returns latency statistics for the recorded actions
+func (t *ActionTimings) Stats() *ActionTimingStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &ActionTimingStats{
+		Count:        len(t.fromActual),
+		Failures:     t.failures,
+		FromIntended: computeLatencyStats(t.fromIntended),
+		FromActual:   computeLatencyStats(t.fromActual),
+		StartDelay:   computeLatencyStats(t.startDelays),
+	}
+}
+
+// ArrivalScheduler issues actions on an open-model timeline: arrivals are
+// scheduled at a fixed rate regardless of how long earlier actions take
+type ArrivalScheduler struct {
+	pool     *UserPool
+	mode     string
+	interval time.Duration
+	wg       sync.WaitGroup
+}
+
+// NewArrivalScheduler creates a scheduler that dispatches requestsPerMin actions per minute
+func NewArrivalScheduler(pool *UserPool, mode string, requestsPerMin int) *ArrivalScheduler {
+	return &ArrivalScheduler{
+		pool:     pool,
+		mode:     mode,
+		interval: time.Duration(float64(time.Minute) / float64(requestsPerMin)),
+	}
+}
+
+// Run dispatches actions until stopChan closes, then waits for in-flight actions
+func (s *ArrivalScheduler) Run(stopChan <-chan bool) {
+	defer s.wg.Wait()
+
+	intended := time.Now()
+	for {
+		intended = intended.Add(s.nextGap())
+
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(time.Until(intended)):
+		}
+
+		users := s.pool.Users()
+		if len(users) == 0 {
+			continue
+		}
+		user := users[rand.Intn(len(users))]
+
+		// Each arrival runs independently so a slow response never delays the next one
+		s.wg.Add(1)
+		go func(u *UserSimulator, intended time.Time) {
+			defer s.wg.Done()
+			if !u.IsConnected() {
+				return
+			}
+			u.act(intended)
+		}(user, intended)
+	}
+}
+
+// nextGap returns the time until the next arrival
+func (s *ArrivalScheduler) nextGap() time.Duration {
+	if s.mode == PacingPoisson {
+		return time.Duration(rand.ExpFloat64() * float64(s.interval))
+	}
+	return s.interval
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Series roles a persona can be given
+const (
+	RoleMember      = "member"
+	RoleFacilitator = "facilitator"
+	RoleAdmin       = "admin"
+)
+
+// Persona is a class of simulated user with its own role, action mix and pacing
+type Persona struct {
+	Name      string
+	Count     int // 0 means "all users not claimed by another persona"
+	Role      string
+	Actions   map[string]int
+	ThinkTime ThinkTime // Zero value means use the global think time
+}
+
+// PersonaSpec is the scenario file form of a Persona
+type PersonaSpec struct {
+	Name      string         `json:"name" yaml:"name"`
+	Count     int            `json:"count" yaml:"count"`
+	Role      string         `json:"role" yaml:"role"`
+	Actions   map[string]int `json:"actions" yaml:"actions"`
+	ThinkTime *ThinkTimeSpec `json:"think_time" yaml:"think_time"`
+}
+
+// Persona converts the spec and validates it
+func (s PersonaSpec) Persona() (Persona, error) {
+	p := Persona{
+		Name:    s.Name,
+		Count:   s.Count,
+		Role:    s.Role,
+		Actions: s.Actions,
+	}
+	if p.Role == "" {
+		p.Role = RoleMember
+	}
+	if s.ThinkTime != nil {
+		thinkTime, err := s.ThinkTime.ThinkTime()
+		if err != nil {
+			return p, fmt.Errorf("persona %q think_time: %w", s.Name, err)
+		}
+		p.ThinkTime = thinkTime
+	}
+	return p, p.Validate()
+}
+
+// Validate checks the persona's role and action table
+func (p Persona) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("persona needs a name")
+	}
+	if p.Count < 0 {
+		return fmt.Errorf("persona %q: count must not be negative", p.Name)
+	}
+	switch p.Role {
+	case RoleMember, RoleFacilitator, RoleAdmin:
+	default:
+		return fmt.Errorf("persona %q: unknown role %q (use %s, %s or %s)", p.Name, p.Role, RoleMember, RoleFacilitator, RoleAdmin)
+	}
+
+	total := 0
+	for name, weight := range p.Actions {
+		if !IsKnownAction(name) {
+			return fmt.Errorf("persona %q: unknown action %q (known: %s)", p.Name, name, strings.Join(KnownActions(), ", "))
+		}
+		if weight < 0 {
+			return fmt.Errorf("persona %q: action %q has negative weight", p.Name, name)
+		}
+		if weight > 0 && IsFacilitatorAction(name) && !p.CanFacilitate() {
+			return fmt.Errorf("persona %q: action %q needs the facilitator or admin role", p.Name, name)
+		}
+		total += weight
+	}
+	if len(p.Actions) > 0 && total == 0 {
+		return fmt.Errorf("persona %q: action weights must not all be zero", p.Name)
+	}
+	return nil
+}
+
+// CanFacilitate reports whether the persona's role may drive the meeting
+func (p Persona) CanFacilitate() bool {
+	return p.Role == RoleFacilitator || p.Role == RoleAdmin
+}
+
+// DefaultPersonas returns the single participant persona used when a run defines none
+func DefaultPersonas(config *Config) []Persona {
+	return []Persona{
+		{
+			Name:    "participant",
+			Role:    RoleMember,
+			Actions: config.ActionWeights,
+		},
+	}
+}
+
+// ValidatePersonas checks a persona list as a whole
+func ValidatePersonas(personas []Persona) error {
+	names := make(map[string]bool)
+	rest := 0
+	for _, p := range personas {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate persona %q", p.Name)
+		}
+		names[p.Name] = true
+		if p.Count == 0 {
+			rest++
+		}
+	}
+	if rest > 1 {
+		return fmt.Errorf("only one persona may omit count (it receives all remaining users)")
+	}
+	return nil
+}
+
+// AssignPersona returns the persona for the userID'th user to be spawned.
+// Personas with a fixed count claim the first users in the order they are
+// listed, so facilitators connect first and are retired last; everyone
+// after that gets the persona without a count (or the last persona).
+func AssignPersona(personas []Persona, userID int) Persona {
+	remaining := userID
+	var rest *Persona
+	for i := range personas {
+		if personas[i].Count == 0 {
+			if rest == nil {
+				rest = &personas[i]
+			}
+			continue
+		}
+		if remaining <= personas[i].Count {
+			return personas[i]
+		}
+		remaining -= personas[i].Count
+	}
+	if rest != nil {
+		return *rest
+	}
+	return personas[len(personas)-1]
+}
+
+// PersonaNames returns the persona names in sorted order
+func PersonaNames(personas map[string]*PersonaStats) []string {
+	names := make([]string, 0, len(personas))
+	for name := range personas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
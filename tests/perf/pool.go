@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// UserPool tracks the users that are currently connected and active
+type UserPool struct {
+	users []*UserSimulator
+	peak  int
+	mu    sync.RWMutex
+}
+
+// NewUserPool creates an empty user pool
+func NewUserPool() *UserPool {
+	return &UserPool{
+		users: make([]*UserSimulator, 0),
+	}
+}
+
+// Add adds a user to the pool and returns the new pool size
+func (p *UserPool) Add(user *UserSimulator) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.users = append(p.users, user)
+	if len(p.users) > p.peak {
+		p.peak = len(p.users)
+	}
+	return len(p.users)
+}
+
+// RemoveNewest removes the most recently added user, returning nil if the pool is empty
+func (p *UserPool) RemoveNewest() *UserSimulator {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.users) == 0 {
+		return nil
+	}
+	user := p.users[len(p.users)-1]
+	p.users = p.users[:len(p.users)-1]
+	return user
+}
+
+// Users returns a snapshot of the users in the pool
+func (p *UserPool) Users() []*UserSimulator {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]*UserSimulator{}, p.users...)
+}
+
+// Count returns the number of users in the pool
+func (p *UserPool) Count() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.users)
+}
+
+// Peak returns the largest pool size seen
+func (p *UserPool) Peak() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.peak
+}
+
+// UserSpawner creates, connects and retires simulated users, placing each
+// new user on the board with the most room left
+type UserSpawner struct {
+	pool        *UserPool
+	adminAPI    *APIClient
+	boards      []*BoardTarget
+	correlator  *EventCorrelator
+	config      *Config
+	rateLimiter <-chan time.Time
+	stopChan    chan bool
+	wg          *sync.WaitGroup
+	all         []*UserSimulator
+	nextID      int
+	spawned     int
+	connected   int
+	byBoard     map[string]int // board -> users who connected to it
+	live        map[string]int // board -> users placed on it and not yet retired
+	failed      int
+	mu          sync.Mutex
+}
+
+// NewUserSpawner creates a spawner that adds users to pool. boards must not
+// be empty; every board's run shares the first's correlator and config.
+func NewUserSpawner(pool *UserPool, adminAPI *APIClient, boards []*BoardTarget,
+	rateLimiter <-chan time.Time, stopChan chan bool, wg *sync.WaitGroup) *UserSpawner {
+	return &UserSpawner{
+		pool:        pool,
+		adminAPI:    adminAPI,
+		boards:      boards,
+		byBoard:     make(map[string]int),
+		live:        make(map[string]int),
+		correlator:  boards[0].Run.Correlator,
+		config:      boards[0].Run.Config,
+		rateLimiter: rateLimiter,
+		stopChan:    stopChan,
+		wg:          wg,
+	}
+}
+
+// SpawnOne registers and connects one more user. Only rate limiting is
+// returned as an error; other failures are counted and logged.
+func (s *UserSpawner) SpawnOne(target int) error {
+	s.mu.Lock()
+	s.nextID++
+	userID := s.nextID
+	s.spawned++
+	board := roomiestBoard(s.boards, s.live)
+	s.live[board.ID]++
+	s.mu.Unlock()
+
+	persona := AssignPersona(s.config.Personas, userID)
+	user := NewUserSimulator(userID, board.ID, board.ColumnIDs, persona, board.Run)
+	s.correlator.SetUserBoard(userID, board.ID)
+
+	if err := user.Setup(); err != nil {
+		if isRateLimited(err) {
+			PrintRateLimitHelp("RATE LIMIT DETECTED DURING USER SPAWNING")
+			s.release(board.ID)
+			return fmt.Errorf("rate limit detected - set DISABLE_RATE_LIMITING=true on the server")
+		}
+		PrintError("Spawn", fmt.Sprintf("User %d setup failed: %v", userID, err))
+		s.recordFailure(board.ID)
+		return nil
+	}
+
+	// Add user to series with the persona's role (admin API call required)
+	if err := s.adminAPI.AddUserToSeries(board.SeriesID, user.ctx.Email, persona.Role); err != nil {
+		PrintError("Spawn", fmt.Sprintf("User %d add to series failed: %v", userID, err))
+		s.recordFailure(board.ID)
+		user.Stop()
+		return nil
+	}
+
+	s.mu.Lock()
+	s.connected++
+	s.byBoard[board.ID]++
+	s.all = append(s.all, user)
+	s.mu.Unlock()
+
+	// Expect this user to receive everything sent from now on
+	s.correlator.SetUserPersona(userID, persona.Name)
+	count := s.pool.Add(user)
+	s.correlator.UserConnected(userID)
+
+	// Start user activity in background
+	s.wg.Add(1)
+	go func(u *UserSimulator) {
+		defer s.wg.Done()
+		u.Start(s.stopChan, s.rateLimiter)
+	}(user)
+
+	PrintSuccess("Spawn", fmt.Sprintf("User %d (%s) connected (%d/%d)", userID, persona.Name, count, target))
+	return nil
+}
+
+// StopOne disconnects the most recently spawned user
+func (s *UserSpawner) StopOne() {
+	user := s.pool.RemoveNewest()
+	if user == nil {
+		return
+	}
+	s.correlator.UserDisconnected(user.GetID())
+	user.Stop()
+	s.release(user.boardID)
+
+	if s.config.Verbose {
+		fmt.Printf("User %d disconnected (ramp-down)\n", user.GetID())
+	}
+}
+
+// StopAll disconnects every user in the pool
+func (s *UserSpawner) StopAll() {
+	for s.pool.Count() > 0 {
+		s.StopOne()
+	}
+}
+
+// Counts returns how many users were attempted, connected and failed
+func (s *UserSpawner) Counts() (spawned, connected, failed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.spawned, s.connected, s.failed
+}
+
+// ConnectedByBoard returns how many users connected to each board
+func (s *UserSpawner) ConnectedByBoard() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]int, len(s.byBoard))
+	for boardID, count := range s.byBoard {
+		counts[boardID] = count
+	}
+	return counts
+}
+
+// AllUsers returns every user that connected during the run, including retired ones
+func (s *UserSpawner) AllUsers() []*UserSimulator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*UserSimulator{}, s.all...)
+}
+
+// recordFailure counts a user who never connected, freeing their place on boardID
+func (s *UserSpawner) recordFailure(boardID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed++
+	s.live[boardID]--
+}
+
+// release frees a place on boardID
+func (s *UserSpawner) release(boardID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.live[boardID]--
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// presenceSettle is how long after a user joins or leaves a view may still
+// disagree about them without counting as divergent
+const presenceSettle = 3 * time.Second
+
+// presenceEvents are the SSE messages about who is on the board. They are
+// checked against the presence model instead of being correlated, since no
+// action sends them.
+var presenceEvents = map[string]bool{
+	"user_joined":     true,
+	"user_left":       true,
+	"presence_update": true,
+	"presence_ping":   true,
+}
+
+// presenceMember is one simulated user in the model
+type presenceMember struct {
+	userID  int
+	present bool
+	changed time.Time
+}
+
+// presenceTransition is one join or leave, waiting for every client
+// connected at the time to see it
+type presenceTransition struct {
+	member    string
+	join      bool
+	at        time.Time
+	pending   map[int]bool // Clients yet to see the change
+	converged []time.Duration
+}
+
+// presenceDivergence counts views that disagreed with the model
+type presenceDivergence struct {
+	checks    int
+	divergent int
+	missing   int
+	ghosts    int
+}
+
+// check compares one view with the members, skipping those that changed
+// within presenceSettle of at
+func (d *presenceDivergence) check(members map[string]*presenceMember, view map[string]bool, at time.Time) {
+	d.checks++
+	missing, ghosts := 0, 0
+	for id, member := range members {
+		if at.Sub(member.changed) < presenceSettle {
+			continue
+		}
+		if member.present && !view[id] {
+			missing++
+		} else if !member.present && view[id] {
+			ghosts++
+		}
+	}
+	if missing+ghosts > 0 {
+		d.divergent++
+	}
+	d.missing += missing
+	d.ghosts += ghosts
+}
+
+// PresenceTracker keeps its own model of which simulated users are on the
+// board, keyed by server user ID, and checks every presence list it sees
+// against it: the one carried by each user's presence events, and the one
+// the admin polls from /presence. Users outside the model, such as the
+// admin, are ignored. Each join and leave is also followed until every
+// client connected at the time has seen it.
+type PresenceTracker struct {
+	api          *APIClient
+	boardID      string
+	interval     time.Duration
+	members      map[string]*presenceMember
+	open         []*presenceTransition
+	closed       []*presenceTransition
+	events       presenceDivergence
+	polls        presenceDivergence
+	pollFailures int
+	pongs        int
+	pongFailures int
+	stopped      bool
+	mu           sync.Mutex
+}
+
+// NewPresenceTracker creates a tracker polling the board's presence every
+// interval; zero disables polling
+func NewPresenceTracker(api *APIClient, boardID string, interval time.Duration) *PresenceTracker {
+	return &PresenceTracker{
+		api:      api,
+		boardID:  boardID,
+		interval: interval,
+		members:  make(map[string]*presenceMember),
+	}
+}
+
+// Join records that simulated user userID, known to the server as member,
+// connected at
+func (p *PresenceTracker) Join(member string, userID int, at time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	p.transition(member, true, at)
+	p.members[member] = &presenceMember{userID: userID, present: true, changed: at}
+}
+
+// Leave records that member disconnected
+func (p *PresenceTracker) Leave(member string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current, ok := p.members[member]
+	if p.stopped || !ok || !current.present {
+		return
+	}
+	now := time.Now()
+	current.present = false
+	current.changed = now
+	p.transition(member, false, now)
+
+	// A departed client can no longer see anyone else come or go
+	for _, open := range p.open {
+		delete(open.pending, current.userID)
+	}
+}
+
+// transition starts following a change to member, replacing any earlier
+// change to them that some clients have not seen yet
+func (p *PresenceTracker) transition(member string, join bool, at time.Time) {
+	t := &presenceTransition{member: member, join: join, at: at, pending: make(map[int]bool)}
+	for id, m := range p.members {
+		if m.present && id != member {
+			t.pending[m.userID] = true
+		}
+	}
+
+	open := p.open[:0]
+	for _, previous := range p.open {
+		if previous.member == member {
+			previous.pending = nil
+			p.closed = append(p.closed, previous)
+		} else {
+			open = append(open, previous)
+		}
+	}
+	p.open = append(open, t)
+}
+
+// Observe checks the presence list userID received at against the model
+// and advances every change the list shows userID has now seen
+func (p *PresenceTracker) Observe(userID int, at time.Time, view []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+
+	present := make(map[string]bool, len(view))
+	for _, id := range view {
+		present[id] = true
+	}
+	p.events.check(p.members, present, at)
+
+	open := p.open[:0]
+	for _, t := range p.open {
+		if t.pending[userID] && !at.Before(t.at) && present[t.member] == t.join {
+			t.converged = append(t.converged, at.Sub(t.at))
+			delete(t.pending, userID)
+		}
+		if len(t.pending) == 0 {
+			p.closed = append(p.closed, t)
+		} else {
+			open = append(open, t)
+		}
+	}
+	p.open = open
+}
+
+// RecordPong stores the outcome of one user answering a presence_ping
+func (p *PresenceTracker) RecordPong(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.pongFailures++
+		return
+	}
+	p.pongs++
+}
+
+// Run polls /presence every interval until stopChan closes
+func (p *PresenceTracker) Run(stopChan <-chan bool) {
+	if p.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+func (p *PresenceTracker) poll() {
+	data, err := p.api.GetPresence(p.boardID)
+	at := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	if err != nil {
+		p.pollFailures++
+		PrintWarning("Presence", fmt.Sprintf("Poll failed: %v", err))
+		return
+	}
+	present := make(map[string]bool, len(data.Presence))
+	for _, user := range data.Presence {
+		present[user.UserID] = true
+	}
+	p.polls.check(p.members, present, at)
+}
+
+// Stop freezes the model, so users disconnecting at the end of the run do
+// not count as joins and leaves still to be seen
+func (p *PresenceTracker) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopped = true
+}
+
+// Report returns divergence from the model and how long joins and leaves
+// took to reach every client
+func (p *PresenceTracker) Report() *PresenceReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := &PresenceReport{
+		PollInterval:   p.interval,
+		EventChecks:    p.events.checks,
+		EventDivergent: p.events.divergent,
+		EventMissing:   p.events.missing,
+		EventGhosts:    p.events.ghosts,
+		Polls:          p.polls.checks,
+		PollFailures:   p.pollFailures,
+		PollDivergent:  p.polls.divergent,
+		PollMissing:    p.polls.missing,
+		PollGhosts:     p.polls.ghosts,
+		Pongs:          p.pongs,
+		PongFailures:   p.pongFailures,
+	}
+	var joins, leaves []time.Duration
+	for _, t := range append(append([]*presenceTransition{}, p.closed...), p.open...) {
+		if t.join {
+			report.Joins++
+			joins = append(joins, t.converged...)
+			report.JoinsUnseen += len(t.pending)
+		} else {
+			report.Leaves++
+			leaves = append(leaves, t.converged...)
+			report.LeavesUnseen += len(t.pending)
+		}
+	}
+	report.JoinConvergence = computeLatencyStats(joins)
+	report.LeaveConvergence = computeLatencyStats(leaves)
+	return report
+}
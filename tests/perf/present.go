@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Present session timing and filter
+const (
+	presentSettleDelay = 2 * time.Second // Lets the last selection of a pass reach every client before checking
+	presentFilter      = "votes"         // The only filter that needs no quadrant scene
+)
+
+// presentPass is one walk through every card
+type presentPass struct {
+	number        int
+	filterEventID string
+	stepEventIDs  []string
+	selected      string         // Card the facilitator left selected
+	seen          map[int]string // user -> card their latest update_presentation showed
+	users         int
+	stale         int
+	missed        int
+	err           error
+}
+
+// PresentSession runs a present mode scene the way a facilitator does:
+// set the filter, then step through the cards one after another. Every
+// client's latest selection is recorded so each pass can end by counting
+// the clients left showing a card the facilitator already moved past.
+type PresentSession struct {
+	api           *APIClient
+	boardID       string
+	scene         Scene
+	cardIDs       []string
+	step          time.Duration
+	meeting       *MeetingState
+	pool          *UserPool
+	correlator    *EventCorrelator
+	presenting    bool
+	passes        []*presentPass
+	current       *presentPass
+	loads         int
+	loadFailures  int
+	loadLatencies []time.Duration
+	report        PresentReport
+	mu            sync.Mutex
+}
+
+// NewPresentSession creates a driver stepping through cardIDs on scene, a
+// present mode scene, every step
+func NewPresentSession(api *APIClient, boardID string, scene Scene, cardIDs []string, step time.Duration,
+	meeting *MeetingState, pool *UserPool, correlator *EventCorrelator) *PresentSession {
+	return &PresentSession{
+		api:        api,
+		boardID:    boardID,
+		scene:      scene,
+		cardIDs:    cardIDs,
+		step:       step,
+		meeting:    meeting,
+		pool:       pool,
+		correlator: correlator,
+		report:     PresentReport{Scene: scene.Title, Cards: len(cardIDs), Step: step},
+	}
+}
+
+// Presenting reports whether the board is showing the present scene
+func (p *PresentSession) Presenting() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.presenting
+}
+
+// RecordSelection stores the card userID's latest update_presentation left selected
+func (p *PresentSession) RecordSelection(userID int, cardID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.current != nil {
+		p.current.seen[userID] = cardID
+	}
+}
+
+// RecordLoad stores one participant's present data fetch
+func (p *PresentSession) RecordLoad(latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.loadFailures++
+		return
+	}
+	p.loads++
+	p.loadLatencies = append(p.loadLatencies, latency)
+}
+
+// Run moves the board to the present scene and steps through its cards,
+// pass after pass, until stopChan closes
+func (p *PresentSession) Run(stopChan <-chan bool) {
+	if err := p.api.ChangeScene(p.boardID, p.scene.ID); err != nil {
+		p.fail(fmt.Errorf("change to present scene: %w", err))
+		return
+	}
+	p.correlator.RecordSentEvent("scene_changed", p.scene.ID, 0)
+	p.meeting.SetScene(p.scene)
+	p.mu.Lock()
+	p.presenting = true
+	p.mu.Unlock()
+	PrintInfo("Present", fmt.Sprintf("Presenting %q with %d cards", p.scene.Title, len(p.cardIDs)))
+
+	if waitOrStop(presentSettleDelay, stopChan) {
+		return
+	}
+
+	for number := 1; ; number++ {
+		pass, done := p.runPass(number, stopChan)
+		if pass.err != nil {
+			PrintWarning("Present", fmt.Sprintf("Pass %d: %v", number, pass.err))
+		} else if !done {
+			PrintInfo("Present", fmt.Sprintf("Pass %d: %d cards, %d/%d clients stale, %d missed",
+				number, len(pass.stepEventIDs), pass.stale, pass.users, pass.missed))
+		}
+		if done {
+			return
+		}
+	}
+}
+
+// runPass sets the filter and selects every card in turn, reporting whether
+// the run should stop afterwards
+func (p *PresentSession) runPass(number int, stopChan <-chan bool) (*presentPass, bool) {
+	pass := &presentPass{number: number, seen: make(map[int]string)}
+	p.mu.Lock()
+	p.passes = append(p.passes, pass)
+	p.current = pass
+	p.mu.Unlock()
+
+	if err := p.api.SetPresentFilter(p.scene.ID, presentFilter); err != nil {
+		pass.err = fmt.Errorf("set filter: %w", err)
+		return pass, waitOrStop(presentSettleDelay, stopChan)
+	}
+	pass.filterEventID = p.correlator.RecordSentEvent("present_filter_changed", p.scene.ID, 0)
+
+	for _, cardID := range p.cardIDs {
+		if err := p.api.SelectCard(p.scene.ID, cardID); err != nil {
+			pass.err = fmt.Errorf("select card: %w", err)
+			return pass, waitOrStop(presentSettleDelay, stopChan)
+		}
+		// Tag by card so a late receipt is not credited to the next step
+		eventID := p.correlator.RecordTaggedEvent("update_presentation", p.scene.ID, 0, cardTag(cardID))
+		p.mu.Lock()
+		pass.stepEventIDs = append(pass.stepEventIDs, eventID)
+		pass.selected = cardID
+		p.mu.Unlock()
+		if waitOrStop(p.step, stopChan) {
+			return pass, true
+		}
+	}
+
+	if waitOrStop(presentSettleDelay, stopChan) {
+		return pass, true
+	}
+	p.check(pass)
+	return pass, false
+}
+
+// check counts the connected clients not showing the pass's final selection
+func (p *PresentSession) check(pass *presentPass) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, u := range p.pool.Users() {
+		if !u.IsConnected() {
+			continue
+		}
+		pass.users++
+		seen, ok := pass.seen[u.GetID()]
+		if !ok {
+			pass.missed++
+		} else if seen != pass.selected {
+			pass.stale++
+		}
+	}
+}
+
+// Report returns the session results, including delivery of each pass's
+// filter change and card selections
+func (p *PresentSession) Report() *PresentReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := p.report
+	report.Loads = p.loads
+	report.LoadFailures = p.loadFailures
+	report.LoadLatency = computeLatencyStats(p.loadLatencies)
+	for _, pass := range p.passes {
+		passReport := PresentPass{
+			Pass:   pass.number,
+			Users:  pass.users,
+			Steps:  len(pass.stepEventIDs),
+			Stale:  pass.stale,
+			Missed: pass.missed,
+		}
+		if pass.err != nil {
+			passReport.Error = pass.err.Error()
+		}
+		if pass.filterEventID != "" {
+			passReport.FilterExpected, passReport.FilterReceived, passReport.FilterFanOut = p.correlator.DeliveryFor(pass.filterEventID)
+		}
+		var fanOuts []time.Duration
+		for _, eventID := range pass.stepEventIDs {
+			expected, received, fanOut := p.correlator.DeliveryFor(eventID)
+			passReport.StepExpected += expected
+			passReport.StepReceived += received
+			fanOuts = append(fanOuts, fanOut)
+		}
+		passReport.StepFanOut = computeLatencyStats(fanOuts)
+		report.Passes = append(report.Passes, passReport)
+	}
+	return &report
+}
+
+func (p *PresentSession) fail(err error) {
+	p.report.Error = err.Error()
+	PrintError("Present", err.Error())
+}
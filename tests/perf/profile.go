@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stage is one segment of a load profile: the user count ramps linearly from
+// the previous stage's target to Users over Duration. A zero duration jumps
+// straight to the new target (a spike).
+type Stage struct {
+	Duration time.Duration
+	Users    int
+}
+
+// StageSpec is the scenario file form of a Stage
+type StageSpec struct {
+	Duration Duration `json:"duration" yaml:"duration"`
+	Users    int      `json:"users" yaml:"users"`
+}
+
+// LoadProfile computes the target number of connected users over time
+type LoadProfile struct {
+	Stages []Stage
+}
+
+// ParseStages parses a stage list such as "2m:50,10m:50,0s:150,5m:150,2m:0"
+func ParseStages(spec string) ([]Stage, error) {
+	var stages []Stage
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		durationStr, usersStr, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("stage %q must be duration:users", part)
+		}
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil {
+			return nil, fmt.Errorf("stage %q: invalid duration: %w", part, err)
+		}
+		users, err := strconv.Atoi(usersStr)
+		if err != nil {
+			return nil, fmt.Errorf("stage %q: invalid user count: %w", part, err)
+		}
+		if duration < 0 || users < 0 {
+			return nil, fmt.Errorf("stage %q: duration and users must not be negative", part)
+		}
+		stages = append(stages, Stage{Duration: duration, Users: users})
+	}
+	return stages, nil
+}
+
+// NewLoadProfile creates a profile from the configured stages. Without stages
+// the profile holds ConcurrentUsers flat for the whole test duration.
+func NewLoadProfile(config *Config) *LoadProfile {
+	if len(config.Stages) == 0 {
+		return &LoadProfile{
+			Stages: []Stage{
+				{Duration: 0, Users: config.ConcurrentUsers},
+				{Duration: config.TestDuration, Users: config.ConcurrentUsers},
+			},
+		}
+	}
+	return &LoadProfile{Stages: config.Stages}
+}
+
+// TargetAt returns the number of users that should be connected after elapsed
+func (p *LoadProfile) TargetAt(elapsed time.Duration) int {
+	from := 0
+	for _, stage := range p.Stages {
+		if elapsed < stage.Duration {
+			progress := float64(elapsed) / float64(stage.Duration)
+			return from + int(float64(stage.Users-from)*progress+0.5)
+		}
+		elapsed -= stage.Duration
+		from = stage.Users
+	}
+	return from
+}
+
+// TotalDuration returns the sum of all stage durations
+func (p *LoadProfile) TotalDuration() time.Duration {
+	var total time.Duration
+	for _, stage := range p.Stages {
+		total += stage.Duration
+	}
+	return total
+}
+
+// Peak returns the highest user count any stage targets
+func (p *LoadProfile) Peak() int {
+	peak := 0
+	for _, stage := range p.Stages {
+		if stage.Users > peak {
+			peak = stage.Users
+		}
+	}
+	return peak
+}
+
+// FormatStages renders stages in the same form ParseStages accepts
+func FormatStages(stages []Stage) string {
+	parts := make([]string, len(stages))
+	for i, stage := range stages {
+		parts[i] = fmt.Sprintf("%v:%d", stage.Duration, stage.Users)
+	}
+	return strings.Join(parts, ",")
+}
+
+// RunLoadProfile adds and removes users so the pool follows the profile until stopChan closes
+func RunLoadProfile(profile *LoadProfile, spawner *UserSpawner, startTime time.Time, stopChan <-chan bool) error {
+	for {
+		select {
+		case <-stopChan:
+			return nil
+		default:
+		}
+
+		target := profile.TargetAt(time.Since(startTime))
+		active := spawner.pool.Count()
+
+		switch {
+		case active < target:
+			if err := spawner.SpawnOne(target); err != nil {
+				return err
+			}
+			time.Sleep(spawner.config.SpawnInterval)
+		case active > target:
+			spawner.StopOne()
+		default:
+			select {
+			case <-stopChan:
+				return nil
+			case <-time.After(250 * time.Millisecond):
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Quadrant session timing and limits
+const (
+	quadrantSettleDelay  = 2 * time.Second // Lets clients handle the scene change before input opens
+	quadrantResultsHold  = 5 * time.Second // Time spent in the results phase before the next cycle
+	quadrantAdjustments  = 3               // Consensus cards the facilitator moves each cycle
+	quadrantPositionMax  = 96              // Positions run from 1 to quadrantPositionMax on both axes
+	quadrantConfigString = `{"grid_size":"2x2","x_axis_label":"Effort","y_axis_label":"Impact","x_axis_values":["Low","High"],"y_axis_values":["Low","High"]}`
+)
+
+// randomQuadrantPosition returns a random valid x or y value
+func randomQuadrantPosition() int {
+	return 1 + rand.Intn(quadrantPositionMax)
+}
+
+// QuadrantSession runs a quadrant scene the way a facilitator does: open the
+// input phase, let every user drag cards around, calculate consensus, adjust
+// a few results, reset and start over
+type QuadrantSession struct {
+	api               *APIClient
+	boardID           string
+	scene             Scene
+	cardIDs           []string
+	inputDuration     time.Duration
+	meeting           *MeetingState
+	correlator        *EventCorrelator
+	accepting         bool
+	positions         int
+	positionFailures  int
+	positionLatencies []time.Duration
+	report            QuadrantReport
+	mu                sync.Mutex
+}
+
+// NewQuadrantSession creates a driver for scene, a quadrant scene holding cardIDs
+func NewQuadrantSession(api *APIClient, boardID string, scene Scene, cardIDs []string, inputDuration time.Duration,
+	meeting *MeetingState, correlator *EventCorrelator) *QuadrantSession {
+	return &QuadrantSession{
+		api:           api,
+		boardID:       boardID,
+		scene:         scene,
+		cardIDs:       cardIDs,
+		inputDuration: inputDuration,
+		meeting:       meeting,
+		correlator:    correlator,
+		report:        QuadrantReport{Scene: scene.Title, Cards: len(cardIDs)},
+	}
+}
+
+// AcceptingPositions reports whether the input phase is open
+func (q *QuadrantSession) AcceptingPositions() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.accepting
+}
+
+// PickCard returns the quadrant scene and one of its cards to drag
+func (q *QuadrantSession) PickCard() (sceneID, cardID string) {
+	return q.scene.ID, q.cardIDs[rand.Intn(len(q.cardIDs))]
+}
+
+// RecordPosition stores one user's drag
+func (q *QuadrantSession) RecordPosition(latency time.Duration, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err != nil {
+		q.positionFailures++
+		return
+	}
+	q.positions++
+	q.positionLatencies = append(q.positionLatencies, latency)
+}
+
+// setAccepting opens or closes the input phase, returning the drags so far
+func (q *QuadrantSession) setAccepting(accepting bool) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.accepting = accepting
+	return q.positions
+}
+
+// Run moves the board to the quadrant scene and repeats input, consensus
+// and reset cycles until stopChan closes
+func (q *QuadrantSession) Run(stopChan <-chan bool) {
+	if err := q.api.ChangeScene(q.boardID, q.scene.ID); err != nil {
+		q.fail(fmt.Errorf("change to quadrant scene: %w", err))
+		return
+	}
+	q.correlator.RecordSentEvent("scene_changed", q.scene.ID, 0)
+	q.meeting.SetScene(q.scene)
+	PrintInfo("Quadrant", fmt.Sprintf("Quadrant scene %q ready with %d cards", q.scene.Title, len(q.cardIDs)))
+
+	if waitOrStop(quadrantSettleDelay, stopChan) {
+		return
+	}
+
+	for number := 1; ; number++ {
+		cycle, done := q.runCycle(number, stopChan)
+		q.report.Cycles = append(q.report.Cycles, cycle)
+		if cycle.Error != "" {
+			PrintWarning("Quadrant", fmt.Sprintf("Cycle %d: %s", number, cycle.Error))
+		} else {
+			PrintInfo("Quadrant", fmt.Sprintf("Cycle %d: %d positions, consensus on %d cards, %d/%d adjustments",
+				number, cycle.Positions, cycle.Consensus, cycle.Adjusted, cycle.Adjusted+cycle.AdjustFailures))
+		}
+		if done {
+			return
+		}
+	}
+}
+
+// runCycle runs one input phase through to reset, reporting whether the run
+// should stop afterwards
+func (q *QuadrantSession) runCycle(number int, stopChan <-chan bool) (QuadrantCycle, bool) {
+	cycle := QuadrantCycle{Cycle: number}
+
+	if err := q.api.StartQuadrantInput(q.scene.ID); err != nil {
+		cycle.Error = fmt.Sprintf("start input: %v", err)
+		return cycle, waitOrStop(quadrantResultsHold, stopChan)
+	}
+	cycle.PhaseEventID = q.correlator.RecordSentEvent("quadrant_phase_changed", q.scene.ID, 0)
+	before := q.setAccepting(true)
+
+	stopped := waitOrStop(q.inputDuration, stopChan)
+	cycle.Positions = q.setAccepting(false) - before
+	if stopped {
+		return cycle, true
+	}
+
+	cardIDs, err := q.api.CalculateConsensus(q.scene.ID)
+	if err != nil {
+		cycle.Error = fmt.Sprintf("calculate consensus: %v", err)
+		return cycle, waitOrStop(quadrantResultsHold, stopChan)
+	}
+	// The scene is current, so the phase change also goes out as scene_changed
+	q.correlator.RecordSentEvent("scene_changed", q.scene.ID, 0)
+	cycle.ResultsEventID = q.correlator.RecordSentEvent("quadrant_results_calculated", q.scene.ID, 0)
+	cycle.Consensus = len(cardIDs)
+
+	rand.Shuffle(len(cardIDs), func(i, j int) { cardIDs[i], cardIDs[j] = cardIDs[j], cardIDs[i] })
+	for _, cardID := range cardIDs[:min(quadrantAdjustments, len(cardIDs))] {
+		if err := q.api.AdjustQuadrantPosition(cardID, q.scene.ID, randomQuadrantPosition(), randomQuadrantPosition()); err != nil {
+			cycle.AdjustFailures++
+			continue
+		}
+		q.correlator.RecordSentEvent("card_quadrant_adjusted", cardID, 0)
+
+		if err := q.api.SetFacilitatorPosition(q.scene.ID, cardID, randomQuadrantPosition(), randomQuadrantPosition()); err != nil {
+			cycle.AdjustFailures++
+			continue
+		}
+		q.correlator.RecordSentEvent("quadrant_facilitator_position_updated", cardID, 0)
+		cycle.Adjusted++
+	}
+
+	stopped = waitOrStop(quadrantResultsHold, stopChan)
+	if err := q.api.ResetQuadrantPositions(q.scene.ID); err != nil {
+		cycle.Error = fmt.Sprintf("reset positions: %v", err)
+	}
+	return cycle, stopped
+}
+
+// Report returns the session results, including delivery of each cycle's
+// phase change and consensus broadcasts
+func (q *QuadrantSession) Report() *QuadrantReport {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	report := q.report
+	report.Positions = q.positions
+	report.PositionFailures = q.positionFailures
+	report.PositionLatency = computeLatencyStats(q.positionLatencies)
+	report.Cycles = append([]QuadrantCycle{}, q.report.Cycles...)
+	for i := range report.Cycles {
+		cycle := &report.Cycles[i]
+		if cycle.PhaseEventID != "" {
+			cycle.PhaseExpected, cycle.PhaseReceived, cycle.PhaseFanOut = q.correlator.DeliveryFor(cycle.PhaseEventID)
+		}
+		if cycle.ResultsEventID != "" {
+			cycle.ResultsExpected, cycle.ResultsReceived, cycle.ResultsFanOut = q.correlator.DeliveryFor(cycle.ResultsEventID)
+		}
+	}
+	return &report
+}
+
+func (q *QuadrantSession) fail(err error) {
+	q.report.Error = err.Error()
+	PrintError("Quadrant", err.Error())
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// replicaCard is one card as a client has pieced it together
+type replicaCard struct {
+	columnID string
+	groupID  string
+	content  string
+}
+
+// BoardReplica is one client's copy of the board, built the way the web
+// client builds it: a GetBoard snapshot on connect, then every SSE event
+// applied in arrival order. Each card remembers when an event last touched
+// it, so a check can leave out cards still changing.
+type BoardReplica struct {
+	cards          map[string]replicaCard
+	votes          map[string]int // card -> votes; nil until the client has seen totals
+	columns        []string
+	touched        map[string]time.Time // card -> last event about it, including deletes
+	columnsChanged time.Time
+	mu             sync.Mutex
+}
+
+// NewBoardReplica starts a replica from a board snapshot and, when the
+// scene shows them, the board's vote totals
+func NewBoardReplica(board *BoardState, votes map[string]int) *BoardReplica {
+	r := &BoardReplica{
+		cards:   make(map[string]replicaCard, len(board.Cards)),
+		touched: make(map[string]time.Time),
+	}
+	for _, card := range board.Cards {
+		r.cards[card.ID] = replicaCard{columnID: card.ColumnID, groupID: card.GroupID, content: card.Content}
+	}
+	for _, column := range board.AllColumns {
+		r.columns = append(r.columns, column.ID)
+	}
+	if votes != nil {
+		r.votes = make(map[string]int, len(votes))
+		for cardID, count := range votes {
+			r.votes[cardID] = count
+		}
+	}
+	return r
+}
+
+// Apply updates the replica with one received event
+func (r *BoardReplica) Apply(event ReceivedEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch event.Type {
+	case "card_created", "card_updated":
+		if event.Card == nil {
+			return
+		}
+		r.cards[event.Card.ID] = replicaCard{
+			columnID: event.Card.ColumnID,
+			groupID:  event.Card.GroupID,
+			content:  event.Card.Content,
+		}
+		r.touched[event.Card.ID] = event.Timestamp
+
+	case "card_deleted":
+		delete(r.cards, event.CardID)
+		r.touched[event.CardID] = event.Timestamp
+
+	case "vote_changed":
+		if event.VoteCount == nil || r.votes == nil {
+			return
+		}
+		r.votes[event.CardID] = *event.VoteCount
+		r.touched[event.CardID] = event.Timestamp
+
+	case "all_votes_updated":
+		if event.AllVotes == nil {
+			break
+		}
+		for cardID := range r.votes {
+			if _, ok := event.AllVotes[cardID]; !ok {
+				r.touched[cardID] = event.Timestamp
+			}
+		}
+		r.votes = make(map[string]int, len(event.AllVotes))
+		for cardID, count := range event.AllVotes {
+			r.votes[cardID] = count
+			r.touched[cardID] = event.Timestamp
+		}
+
+	case "columns_updated":
+		if event.Columns == nil {
+			return
+		}
+		r.columns = event.Columns
+		r.columnsChanged = event.Timestamp
+
+		// Deleting a column takes its cards with it, without a card_deleted
+		kept := make(map[string]bool, len(event.Columns))
+		for _, columnID := range event.Columns {
+			kept[columnID] = true
+		}
+		for cardID, card := range r.cards {
+			if !kept[card.columnID] {
+				delete(r.cards, cardID)
+				r.touched[cardID] = event.Timestamp
+			}
+		}
+	}
+
+	// A clear reaches clients as either all_votes_updated or voting_stats_updated
+	if event.VotesCleared && r.votes != nil {
+		for cardID := range r.votes {
+			r.touched[cardID] = event.Timestamp
+		}
+		r.votes = make(map[string]int)
+	}
+}
+
+// replicaState is a copy of a replica taken for one check
+type replicaState struct {
+	cards          map[string]replicaCard
+	votes          map[string]int
+	columns        []string
+	touched        map[string]time.Time
+	columnsChanged time.Time
+}
+
+// state copies the replica so a check can run without holding it
+func (r *BoardReplica) state() replicaState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state := replicaState{
+		cards:          make(map[string]replicaCard, len(r.cards)),
+		columns:        append([]string{}, r.columns...),
+		touched:        make(map[string]time.Time, len(r.touched)),
+		columnsChanged: r.columnsChanged,
+	}
+	for cardID, card := range r.cards {
+		state.cards[cardID] = card
+	}
+	if r.votes != nil {
+		state.votes = make(map[string]int, len(r.votes))
+		for cardID, count := range r.votes {
+			state.votes[cardID] = count
+		}
+	}
+	for cardID, at := range r.touched {
+		state.touched[cardID] = at
+	}
+	return state
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario describes a named, repeatable workload loaded from a YAML or JSON file.
+// Numbers and durations are pointers so that an explicit 0 is told apart from
+// a value left out.
+type Scenario struct {
+	Name        string          `json:"name" yaml:"name"`
+	URL         string          `json:"url" yaml:"url"`
+	Template    string          `json:"template" yaml:"template"`
+	SceneFlags  []string        `json:"scene_flags" yaml:"scene_flags"`
+	Users       *int            `json:"users" yaml:"users"`
+	Boards      *int            `json:"boards" yaml:"boards"`
+	Series      *int            `json:"series" yaml:"series"`
+	BoardUsers  string          `json:"board_users" yaml:"board_users"` // Users per board, e.g. "5-12"
+	RPM         *int            `json:"rpm" yaml:"rpm"`
+	Pacing      string          `json:"pacing" yaml:"pacing"`
+	Workload    string          `json:"workload" yaml:"workload"`
+	ThinkTime   *ThinkTimeSpec  `json:"think_time" yaml:"think_time"`
+	Actions     map[string]int  `json:"actions" yaml:"actions"`
+	Durations   PhaseDurations  `json:"durations" yaml:"durations"`
+	Stages      []StageSpec     `json:"stages" yaml:"stages"`
+	Personas    []PersonaSpec   `json:"personas" yaml:"personas"`
+	Notes       NotesSpec       `json:"notes" yaml:"notes"`
+	Health      HealthSpec      `json:"health" yaml:"health"`
+	Quadrant    QuadrantSpec    `json:"quadrant" yaml:"quadrant"`
+	Scorecard   ScorecardSpec   `json:"scorecard" yaml:"scorecard"`
+	Timer       TimerSpec       `json:"timer" yaml:"timer"`
+	Present     PresentSpec     `json:"present" yaml:"present"`
+	Voting      VotingSpec      `json:"voting" yaml:"voting"`
+	Churn       ChurnSpec       `json:"churn" yaml:"churn"`
+	Presence    PresenceSpec    `json:"presence" yaml:"presence"`
+	Convergence ConvergenceSpec `json:"convergence" yaml:"convergence"`
+}
+
+// HealthSpec configures the health survey workload
+type HealthSpec struct {
+	Preset string `json:"preset" yaml:"preset"` // Question preset applied to the survey scene
+	Rounds *int   `json:"rounds" yaml:"rounds"` // Synchronized answer bursts spread over the test
+}
+
+// ChurnSpec configures the board admin churn actor
+type ChurnSpec struct {
+	Interval *Duration `json:"interval" yaml:"interval"` // Time between operations; zero disables churn
+}
+
+// PresenceSpec configures the presence checks
+type PresenceSpec struct {
+	Poll *Duration `json:"poll" yaml:"poll"` // Time between presence list polls; zero disables polling
+}
+
+// ConvergenceSpec configures the client board convergence checks
+type ConvergenceSpec struct {
+	Interval *Duration `json:"interval" yaml:"interval"` // Time between checkpoints; zero checks only at the end
+}
+
+// VotingSpec configures the voting workload
+type VotingSpec struct {
+	Cards      *int      `json:"cards" yaml:"cards"`           // Cards users spend their votes on
+	Round      *Duration `json:"round" yaml:"round"`           // Length of each round between clears
+	Allocation *int      `json:"allocation" yaml:"allocation"` // Votes per user at the start of each round
+}
+
+// PresentSpec configures the present mode workload
+type PresentSpec struct {
+	Cards *int      `json:"cards" yaml:"cards"` // Cards the facilitator steps through
+	Step  *Duration `json:"step" yaml:"step"`   // Time between card selections
+}
+
+// TimerSpec configures the timer workload
+type TimerSpec struct {
+	Duration  *Duration `json:"duration" yaml:"duration"`   // Length of each countdown before any extension
+	Tolerance *Duration `json:"tolerance" yaml:"tolerance"` // Allowed spread between a client's countdown and the median
+}
+
+// ScorecardSpec configures the scorecard workload
+type ScorecardSpec struct {
+	Count    *int      `json:"count" yaml:"count"`       // Scorecards attached to the scene
+	Interval *Duration `json:"interval" yaml:"interval"` // Time between data collections
+}
+
+// QuadrantSpec configures the quadrant positioning workload
+type QuadrantSpec struct {
+	Cards *int      `json:"cards" yaml:"cards"` // Cards placed on the quadrant
+	Input *Duration `json:"input" yaml:"input"` // Length of each input phase before consensus
+}
+
+// NotesSpec configures the notes lock contention workload
+type NotesSpec struct {
+	Cards *int      `json:"cards" yaml:"cards"` // Cards the users compete for
+	Hold  *Duration `json:"hold" yaml:"hold"`   // How long a user keeps the lock while editing
+}
+
+// PhaseDurations holds the timing of each phase of a test run
+type PhaseDurations struct {
+	Settle        *Duration `json:"settle" yaml:"settle"`                 // Wait before setup (avoids rate limits from previous runs)
+	Warmup        *Duration `json:"warmup" yaml:"warmup"`                 // Wait between board setup and spawning users
+	SpawnInterval *Duration `json:"spawn_interval" yaml:"spawn_interval"` // Delay between user connections
+	Test          *Duration `json:"test" yaml:"test"`                     // Activity phase
+	Grace         *Duration `json:"grace" yaml:"grace"`                   // Wait for pending events after activity stops
+	Scene         *Duration `json:"scene" yaml:"scene"`                   // Time spent in each scene by the lifecycle workload
+}
+
+// Duration is a time.Duration that unmarshals from strings such as "90s" or "5m"
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\": %w", err)
+	}
+	return d.set(s)
+}
+
+// UnmarshalYAML parses a duration string
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.set(value.Value)
+}
+
+func (d *Duration) set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// LoadScenario reads a scenario file, choosing the decoder by file extension
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scenario: %w", err)
+	}
+
+	scenario := &Scenario{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(scenario); err != nil {
+			return nil, fmt.Errorf("decode scenario %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(scenario); err != nil {
+			return nil, fmt.Errorf("decode scenario %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported scenario format %q (use .yaml, .yml or .json)", filepath.Ext(path))
+	}
+
+	if scenario.Name == "" {
+		scenario.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	if err := scenario.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+
+	return scenario, nil
+}
+
+// Validate checks that the scenario only references known actions and sane values
+func (s *Scenario) Validate() error {
+	if negativeInt(s.Users) {
+		return fmt.Errorf("users must not be negative")
+	}
+	if negativeInt(s.Boards) {
+		return fmt.Errorf("boards must not be negative")
+	}
+	if negativeInt(s.Series) {
+		return fmt.Errorf("series must not be negative")
+	}
+	if s.BoardUsers != "" {
+		if _, _, err := ParseBoardUsers(s.BoardUsers); err != nil {
+			return err
+		}
+	}
+	if negativeInt(s.RPM) {
+		return fmt.Errorf("rpm must not be negative")
+	}
+	if s.Pacing != "" {
+		if err := ValidatePacing(s.Pacing); err != nil {
+			return err
+		}
+	}
+	if s.Workload != "" {
+		if err := ValidateWorkload(s.Workload); err != nil {
+			return err
+		}
+	}
+	if s.ThinkTime != nil {
+		if _, err := s.ThinkTime.ThinkTime(); err != nil {
+			return fmt.Errorf("think_time: %w", err)
+		}
+	}
+	var personas []Persona
+	for _, spec := range s.Personas {
+		persona, err := spec.Persona()
+		if err != nil {
+			return err
+		}
+		personas = append(personas, persona)
+	}
+	if err := ValidatePersonas(personas); err != nil {
+		return err
+	}
+	if negativeInt(s.Health.Rounds) {
+		return fmt.Errorf("health: rounds must not be negative")
+	}
+	if negativeInt(s.Notes.Cards) || negativeDuration(s.Notes.Hold) {
+		return fmt.Errorf("notes: cards and hold must not be negative")
+	}
+	if negativeInt(s.Quadrant.Cards) || negativeDuration(s.Quadrant.Input) {
+		return fmt.Errorf("quadrant: cards and input must not be negative")
+	}
+	if negativeInt(s.Scorecard.Count) || negativeDuration(s.Scorecard.Interval) {
+		return fmt.Errorf("scorecard: count and interval must not be negative")
+	}
+	if negativeDuration(s.Timer.Duration, s.Timer.Tolerance) {
+		return fmt.Errorf("timer: duration and tolerance must not be negative")
+	}
+	if negativeInt(s.Present.Cards) || negativeDuration(s.Present.Step) {
+		return fmt.Errorf("present: cards and step must not be negative")
+	}
+	if negativeInt(s.Voting.Cards, s.Voting.Allocation) || negativeDuration(s.Voting.Round) {
+		return fmt.Errorf("voting: cards, round and allocation must not be negative")
+	}
+	if negativeDuration(s.Churn.Interval) {
+		return fmt.Errorf("churn: interval must not be negative")
+	}
+	if negativeDuration(s.Presence.Poll) {
+		return fmt.Errorf("presence: poll must not be negative")
+	}
+	if negativeDuration(s.Convergence.Interval) {
+		return fmt.Errorf("convergence: interval must not be negative")
+	}
+	for i, stage := range s.Stages {
+		if stage.Duration < 0 || stage.Users < 0 {
+			return fmt.Errorf("stage %d: duration and users must not be negative", i+1)
+		}
+	}
+	total := 0
+	for name, weight := range s.Actions {
+		if !IsKnownAction(name) {
+			return fmt.Errorf("unknown action %q (known: %s)", name, strings.Join(KnownActions(), ", "))
+		}
+		if weight < 0 {
+			return fmt.Errorf("action %q has negative weight", name)
+		}
+		total += weight
+	}
+	if len(s.Actions) > 0 && total == 0 {
+		return fmt.Errorf("action weights must not all be zero")
+	}
+	return nil
+}
+
+// negativeInt reports whether any of values is set and below zero
+func negativeInt(values ...*int) bool {
+	for _, value := range values {
+		if value != nil && *value < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// negativeDuration reports whether any of values is set and below zero
+func negativeDuration(values ...*Duration) bool {
+	for _, value := range values {
+		if value != nil && *value < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply copies scenario values into the config. Fields whose command-line
+// flag was set explicitly are left alone so flags always win over the file.
+func (s *Scenario) Apply(config *Config, explicitFlags map[string]bool) {
+	config.ScenarioName = s.Name
+
+	setString := func(flagName string, dst *string, value string) {
+		if value != "" && !explicitFlags[flagName] {
+			*dst = value
+		}
+	}
+	setInt := func(flagName string, dst *int, value *int) {
+		if value != nil && !explicitFlags[flagName] {
+			*dst = *value
+		}
+	}
+	setDuration := func(flagName string, dst *time.Duration, value *Duration) {
+		if value != nil && !explicitFlags[flagName] {
+			*dst = time.Duration(*value)
+		}
+	}
+
+	setString("url", &config.BaseURL, s.URL)
+	setString("template", &config.Template, s.Template)
+	setString("pacing", &config.Pacing, s.Pacing)
+	setString("workload", &config.Workload, s.Workload)
+	setInt("users", &config.ConcurrentUsers, s.Users)
+	setInt("boards", &config.Boards, s.Boards)
+	setInt("series", &config.Series, s.Series)
+	if s.BoardUsers != "" && !explicitFlags["board-users"] {
+		// Validate has already parsed the range
+		config.BoardUsersMin, config.BoardUsersMax, _ = ParseBoardUsers(s.BoardUsers)
+	}
+	setInt("rpm", &config.RequestsPerMin, s.RPM)
+	setDuration("duration", &config.TestDuration, s.Durations.Test)
+	setDuration("grace", &config.GracePeriod, s.Durations.Grace)
+	setDuration("scene-duration", &config.SceneDuration, s.Durations.Scene)
+	setInt("notes-cards", &config.NotesCards, s.Notes.Cards)
+	setDuration("notes-hold", &config.NotesHold, s.Notes.Hold)
+	setString("health-preset", &config.HealthPreset, s.Health.Preset)
+	setInt("health-rounds", &config.HealthRounds, s.Health.Rounds)
+	setInt("quadrant-cards", &config.QuadrantCards, s.Quadrant.Cards)
+	setDuration("quadrant-input", &config.QuadrantInput, s.Quadrant.Input)
+	setInt("scorecards", &config.Scorecards, s.Scorecard.Count)
+	setDuration("scorecard-interval", &config.ScorecardInterval, s.Scorecard.Interval)
+	setDuration("timer-duration", &config.TimerDuration, s.Timer.Duration)
+	setDuration("timer-tolerance", &config.TimerTolerance, s.Timer.Tolerance)
+	setInt("present-cards", &config.PresentCards, s.Present.Cards)
+	setDuration("present-step", &config.PresentStep, s.Present.Step)
+	setInt("voting-cards", &config.VotingCards, s.Voting.Cards)
+	setDuration("voting-round", &config.VotingRound, s.Voting.Round)
+	setInt("voting-allocation", &config.VotingAllocation, s.Voting.Allocation)
+	setDuration("churn-interval", &config.ChurnInterval, s.Churn.Interval)
+	setDuration("presence-poll", &config.PresencePoll, s.Presence.Poll)
+	setDuration("converge-interval", &config.ConvergeInterval, s.Convergence.Interval)
+
+	// These have no command-line flags
+	setDuration("", &config.SettleDelay, s.Durations.Settle)
+	setDuration("", &config.WarmupDelay, s.Durations.Warmup)
+	setDuration("", &config.SpawnInterval, s.Durations.SpawnInterval)
+
+	if len(s.Stages) > 0 && !explicitFlags["stages"] {
+		config.Stages = make([]Stage, len(s.Stages))
+		for i, stage := range s.Stages {
+			config.Stages[i] = Stage{Duration: time.Duration(stage.Duration), Users: stage.Users}
+		}
+	}
+	if s.ThinkTime != nil && !explicitFlags["think"] {
+		config.ThinkTime, _ = s.ThinkTime.ThinkTime()
+	}
+	if len(s.Personas) > 0 {
+		config.Personas = nil
+		for _, spec := range s.Personas {
+			persona, _ := spec.Persona()
+			config.Personas = append(config.Personas, persona)
+		}
+	}
+	if len(s.SceneFlags) > 0 {
+		config.SceneFlags = append([]string{}, s.SceneFlags...)
+	}
+	if len(s.Actions) > 0 {
+		config.ActionWeights = make(map[string]int, len(s.Actions))
+		for name, weight := range s.Actions {
+			config.ActionWeights[name] = weight
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// schemaMaxExamples caps the mismatching payloads kept for the report
+const schemaMaxExamples = 10
+
+// schemaMaxPayload is how much of a mismatching payload an example keeps
+const schemaMaxPayload = 300
+
+// payloadKind is the JSON type a payload field must have
+type payloadKind int
+
+const (
+	kindString payloadKind = iota
+	kindNumber
+	kindBool
+	kindObject
+	kindArray
+	kindAny // Any type, as long as it is not null
+)
+
+func (k payloadKind) String() string {
+	switch k {
+	case kindString:
+		return "string"
+	case kindNumber:
+		return "number"
+	case kindBool:
+		return "bool"
+	case kindObject:
+		return "object"
+	case kindArray:
+		return "array"
+	}
+	return "value"
+}
+
+// kindOf names the JSON type of a decoded value
+func kindOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// payloadField is one field a payload carries
+type payloadField struct {
+	path     string // Dotted path; "[]" checks every element of an array
+	kind     payloadKind
+	optional bool // May be left out
+	nullable bool // May be null
+}
+
+func requiredField(path string, kind payloadKind) payloadField {
+	return payloadField{path: path, kind: kind}
+}
+
+func optionalField(path string, kind payloadKind) payloadField {
+	return payloadField{path: path, kind: kind, optional: true, nullable: true}
+}
+
+func nullableField(path string, kind payloadKind) payloadField {
+	return payloadField{path: path, kind: kind, nullable: true}
+}
+
+// boardEvent adds the fields every board broadcast carries
+func boardEvent(fields ...payloadField) []payloadField {
+	return append([]payloadField{requiredField("board_id", kindString), requiredField("timestamp", kindNumber)}, fields...)
+}
+
+// cardFields are the fields of a card as broadcast.ts sends it
+var cardFields = []payloadField{
+	requiredField("card", kindObject),
+	requiredField("card.id", kindString),
+	requiredField("card.columnId", kindString),
+	requiredField("card.content", kindString),
+	nullableField("card.groupId", kindString),
+	optionalField("card.isGroupLead", kindBool),
+	optionalField("card.voteCount", kindNumber),
+}
+
+// sceneFields are the fields of a scene as broadcast.ts sends it
+var sceneFields = []payloadField{
+	requiredField("scene", kindObject),
+	requiredField("scene.id", kindString),
+	requiredField("scene.title", kindString),
+	requiredField("scene.mode", kindString),
+}
+
+// payloadSchemas is what each SSE message type carries, following
+// src/lib/server/sse/broadcast.ts and the routes that call it. Fields not
+// listed are allowed, so additions do not break the run; removals, renames
+// and type changes do.
+var payloadSchemas = map[string][]payloadField{
+	"card_created": boardEvent(cardFields...),
+	"card_updated": boardEvent(cardFields...),
+	"card_deleted": boardEvent(requiredField("card_id", kindString)),
+	"vote_changed": boardEvent(
+		requiredField("card_id", kindString),
+		requiredField("vote_count", kindNumber),
+		optionalField("user_id", kindString),
+	),
+	"all_votes_updated": boardEvent(
+		requiredField("all_votes_by_card", kindObject),
+		requiredField("voting_stats", kindObject),
+		optionalField("votes_cleared", kindBool),
+	),
+	"voting_stats_updated": boardEvent(
+		requiredField("voting_stats", kindObject),
+		optionalField("votes_cleared", kindBool),
+	),
+	// The server groups cards with card_updated; these apply if it ever sends them
+	"cards_grouped":     boardEvent(requiredField("groupId", kindString)),
+	"card_grouped_onto": boardEvent(requiredField("cardId", kindString)),
+	"comment_added": boardEvent(
+		requiredField("comment", kindObject),
+		requiredField("comment.id", kindString),
+	),
+	"board_updated": boardEvent(
+		requiredField("board", kindObject),
+		requiredField("board.id", kindString),
+	),
+	"columns_updated": boardEvent(
+		requiredField("columns", kindArray),
+		requiredField("columns[].id", kindString),
+	),
+	"scene_changed": boardEvent(append(append([]payloadField{}, sceneFields...),
+		optionalField("forceReturn", kindBool),
+		optionalField("all_cards", kindArray),
+		optionalField("present_mode_data", kindObject),
+	)...),
+	"scene_created": boardEvent(sceneFields...),
+	"scene_updated": boardEvent(requiredField("scene_id", kindString)),
+	"user_joined": boardEvent(
+		requiredField("user_id", kindString),
+		optionalField("presence_data", kindObject),
+	),
+	"user_left": boardEvent(
+		requiredField("user_id", kindString),
+		optionalField("presence_data", kindObject),
+	),
+	"presence_update": boardEvent(
+		requiredField("user_id", kindString),
+		optionalField("presence_data", kindObject),
+	),
+	"presence_ping": boardEvent(),
+	"update_presentation": boardEvent(
+		optionalField("card_id", kindString),
+		optionalField("comment_id", kindString),
+		optionalField("deleted_comment_id", kindString),
+		optionalField("new_comment", kindObject),
+		optionalField("present_mode_data", kindObject),
+	),
+	"agreements_updated": boardEvent(requiredField("agreements", kindArray)),
+	"timer_update": {
+		requiredField("board_id", kindString),
+		requiredField("data", kindObject),
+		requiredField("data.active", kindBool),
+		requiredField("data.totalUsers", kindNumber),
+		optionalField("data.timer_start", kindString),
+		optionalField("data.timer_passed", kindNumber),
+		optionalField("data.timer_remaining", kindNumber),
+	},
+	"admin_kick": {
+		requiredField("board_id", kindString),
+		requiredField("redirectTo", kindString),
+		requiredField("message", kindString),
+	},
+	"scorecard_attached": boardEvent(
+		requiredField("scene_scorecard", kindObject),
+		requiredField("scene_scorecard.id", kindString),
+	),
+	"scorecard_detached": boardEvent(requiredField("scene_scorecard_id", kindString)),
+	"scorecard_data_collected": boardEvent(
+		requiredField("scene_scorecard_id", kindString),
+		requiredField("processed_at", kindString),
+		requiredField("result_count", kindNumber),
+	),
+	"scorecard_result_flagged": boardEvent(
+		requiredField("result_id", kindString),
+		requiredField("card_id", kindString),
+	),
+	"quadrant_phase_changed": boardEvent(
+		requiredField("scene_id", kindString),
+		requiredField("phase", kindString),
+	),
+	"quadrant_results_calculated": boardEvent(
+		requiredField("scene_id", kindString),
+		requiredField("card_positions", kindArray),
+	),
+	"card_quadrant_adjusted": boardEvent(
+		requiredField("card_id", kindString),
+		requiredField("scene_id", kindString),
+		requiredField("facilitator_x", kindNumber),
+		requiredField("facilitator_y", kindNumber),
+		requiredField("quadrant_label", kindString),
+	),
+	"quadrant_facilitator_position_updated": boardEvent(
+		requiredField("scene_id", kindString),
+		requiredField("card_id", kindString),
+		requiredField("facilitator_x", kindNumber),
+		requiredField("facilitator_y", kindNumber),
+	),
+	"present_filter_changed": boardEvent(
+		requiredField("scene_id", kindString),
+		requiredField("filter", kindAny),
+	),
+}
+
+// checkField lists how value, found at path, breaks field
+func checkField(field payloadField, path string, value interface{}, found bool) []string {
+	if !found {
+		if field.optional {
+			return nil
+		}
+		return []string{path + " missing"}
+	}
+	if value == nil {
+		if field.nullable {
+			return nil
+		}
+		return []string{path + " is null"}
+	}
+	if field.kind == kindAny || kindOf(value) == field.kind.String() {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s is %s, want %s", path, kindOf(value), field.kind)}
+}
+
+// checkPath walks path through data, checking field wherever it leads
+func checkPath(field payloadField, data interface{}, steps []string, walked string) []string {
+	step := steps[0]
+	if name, ok := strings.CutSuffix(step, "[]"); ok {
+		object, _ := data.(map[string]interface{})
+		list, ok := object[name].([]interface{})
+		if !ok {
+			return nil // The array itself is checked by its own field
+		}
+		var problems []string
+		for i, element := range list {
+			at := fmt.Sprintf("%s%s[%d]", walked, name, i)
+			if len(steps) == 1 {
+				problems = append(problems, checkField(field, at, element, true)...)
+			} else {
+				problems = append(problems, checkPath(field, element, steps[1:], at+".")...)
+			}
+			if len(problems) > 0 {
+				return problems // One bad element is enough
+			}
+		}
+		return nil
+	}
+
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		return nil // The parent's own field reports it
+	}
+	value, found := object[step]
+	if len(steps) == 1 {
+		return checkField(field, walked+step, value, found)
+	}
+	if value == nil {
+		return nil
+	}
+	return checkPath(field, value, steps[1:], walked+step+".")
+}
+
+// sentCard is what a user sent for a card, to compare with what clients receive
+type sentCard struct {
+	columnID string
+	content  string
+	at       time.Time
+}
+
+// SchemaValidator checks every SSE payload against payloadSchemas, and the
+// values it can tie to a request against what was sent: the content and
+// column of cards users created or edited, found by the nonce in their
+// content. Which board a payload is for is left to the IsolationChecker.
+type SchemaValidator struct {
+	sent      map[string]sentCard // content nonce -> what was sent
+	nextPrune time.Time
+	report    SchemaReport
+	mu        sync.Mutex
+}
+
+// NewSchemaValidator creates a validator shared by every client of the run
+func NewSchemaValidator() *SchemaValidator {
+	return &SchemaValidator{
+		sent: make(map[string]sentCard),
+		report: SchemaReport{
+			ByType:   make(map[string]int),
+			Problems: make(map[string]int),
+			Unknown:  make(map[string]int),
+		},
+	}
+}
+
+// ExpectCard records the column and content a user is about to send for
+// a card, under the nonce in that content. columnID is "" for an edit,
+// which leaves the card where it is.
+func (v *SchemaValidator) ExpectCard(nonce, columnID, content string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := time.Now()
+	v.sent[nonce] = sentCard{columnID: columnID, content: content, at: now}
+
+	// Receipts come within correlatorRetention, or are not counted anyway
+	if now.After(v.nextPrune) {
+		v.nextPrune = now.Add(correlatorRetention)
+		for key, card := range v.sent {
+			if now.Sub(card.at) > correlatorRetention {
+				delete(v.sent, key)
+			}
+		}
+	}
+}
+
+// Validate checks one payload receiverID received
+func (v *SchemaValidator) Validate(receiverID int, eventType string, data map[string]interface{}, raw string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	schema, ok := payloadSchemas[eventType]
+	if !ok {
+		v.report.Unknown[eventType]++
+		return
+	}
+	v.report.Checked++
+	v.report.ByType[eventType]++
+
+	var problems []string
+	for _, field := range schema {
+		problems = append(problems, checkPath(field, data, strings.Split(field.path, "."), "")...)
+	}
+	problems = append(problems, v.stale(eventType, data)...)
+	if len(problems) == 0 {
+		return
+	}
+
+	v.report.Invalid++
+	for _, problem := range problems {
+		v.report.Problems[eventType+": "+problem]++
+	}
+	if len(v.report.Examples) < schemaMaxExamples {
+		if len(raw) > schemaMaxPayload {
+			raw = raw[:schemaMaxPayload] + "..."
+		}
+		v.report.Examples = append(v.report.Examples, SchemaMismatch{
+			Type:     eventType,
+			UserID:   receiverID,
+			Problems: problems,
+			Payload:  raw,
+		})
+	}
+}
+
+// stale lists values in the payload that differ from what was sent.
+// Callers hold the lock.
+func (v *SchemaValidator) stale(eventType string, data map[string]interface{}) []string {
+	if eventType != "card_created" && eventType != "card_updated" {
+		return nil
+	}
+	card, _ := data["card"].(map[string]interface{})
+	content, _ := card["content"].(string)
+	sent, ok := v.sent[contentNonce(content)]
+	if !ok {
+		return nil
+	}
+
+	var problems []string
+	if content != sent.content {
+		problems = append(problems, "card.content differs from what was sent")
+	}
+	// Only a create is sure to carry the column it sent; a later move keeps the content
+	if columnID, _ := card["columnId"].(string); eventType == "card_created" && sent.columnID != "" && columnID != sent.columnID {
+		problems = append(problems, fmt.Sprintf("card.columnId is %s, want %s", columnID, sent.columnID))
+	}
+	return problems
+}
+
+// Report returns how many payloads were checked and what was wrong with them
+func (v *SchemaValidator) Report() *SchemaReport {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	report := v.report
+	report.ByType = make(map[string]int, len(v.report.ByType))
+	for eventType, count := range v.report.ByType {
+		report.ByType[eventType] = count
+	}
+	report.Problems = make(map[string]int, len(v.report.Problems))
+	for problem, count := range v.report.Problems {
+		report.Problems[problem] = count
+	}
+	report.Unknown = make(map[string]int, len(v.report.Unknown))
+	for eventType, count := range v.report.Unknown {
+		report.Unknown[eventType] = count
+	}
+	report.Examples = append([]SchemaMismatch{}, v.report.Examples...)
+	return &report
+}
+
+// sortedProblems returns the report's problems, most frequent first
+func (r *SchemaReport) sortedProblems() []string {
+	problems := make([]string, 0, len(r.Problems))
+	for problem := range r.Problems {
+		problems = append(problems, problem)
+	}
+	sort.Slice(problems, func(i, j int) bool {
+		if r.Problems[problems[i]] != r.Problems[problems[j]] {
+			return r.Problems[problems[i]] > r.Problems[problems[j]]
+		}
+		return problems[i] < problems[j]
+	})
+	return problems
+}
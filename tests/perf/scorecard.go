@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Scorecard session timing and data shape
+const (
+	scorecardSettleDelay = 2 * time.Second // Lets clients handle the scene change before the first attach
+	scorecardMetrics     = 20              // Rows sent per datasource on each collection
+	scorecardThreshold   = 50              // Rows scoring below this become results
+)
+
+// scorecardRules flags every metric scoring below scorecardThreshold, so
+// random scores yield about half the rows as results
+var scorecardRules = []map[string]interface{}{
+	{
+		"id":             "below-target",
+		"section":        "Below target",
+		"iterate_over":   "metrics",
+		"condition":      []interface{}{"get", "metric.score", "literal", scorecardThreshold, "lt"},
+		"title_template": "{metric.name}",
+		"value_template": "{metric.score}",
+		"severity":       "warning",
+	},
+}
+
+// scorecardSource is a scorecard created for the run with its one datasource
+type scorecardSource struct {
+	scorecard    Scorecard
+	datasourceID string
+}
+
+// SetupScorecards creates count scorecards in the series, each with a paste
+// datasource running scorecardRules
+func SetupScorecards(api *APIClient, seriesID string, count int) ([]scorecardSource, error) {
+	var sources []scorecardSource
+	for i := 1; i <= count; i++ {
+		scorecard, err := api.CreateScorecard(seriesID, fmt.Sprintf("Load Test Scorecard %d", i), "Metrics generated by the load tester")
+		if err != nil {
+			return nil, err
+		}
+		datasource, err := api.CreateScorecardDatasource(scorecard.ID, "Metrics", scorecardRules)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, scorecardSource{scorecard: *scorecard, datasourceID: datasource.ID})
+	}
+	return sources, nil
+}
+
+// scorecardData returns a fresh set of metric rows for datasourceID
+func scorecardData(datasourceID string) map[string]interface{} {
+	metrics := make([]map[string]interface{}, scorecardMetrics)
+	for i := range metrics {
+		metrics[i] = map[string]interface{}{
+			"name":  fmt.Sprintf("Metric %d", i+1),
+			"score": rand.Intn(100),
+		}
+	}
+	return map[string]interface{}{
+		datasourceID: map[string]interface{}{"metrics": metrics},
+	}
+}
+
+// attachedScorecard is a scorecard attached to the session's scene
+type attachedScorecard struct {
+	source           scorecardSource
+	sceneScorecardID string
+}
+
+// ScorecardSession runs a scorecard scene the way a facilitator does: attach
+// the scorecards, then re-collect their data on an interval while
+// participants flag results for discussion
+type ScorecardSession struct {
+	api           *APIClient
+	boardID       string
+	scene         Scene
+	sources       []scorecardSource
+	interval      time.Duration
+	meeting       *MeetingState
+	correlator    *EventCorrelator
+	attached      []attachedScorecard
+	results       map[string][]string // sceneScorecardID -> result IDs that can be flagged
+	flags         int
+	flagFailures  int
+	flagLatencies []time.Duration
+	report        ScorecardReport
+	mu            sync.Mutex
+}
+
+// NewScorecardSession creates a driver attaching sources to scene
+func NewScorecardSession(api *APIClient, boardID string, scene Scene, sources []scorecardSource, interval time.Duration,
+	meeting *MeetingState, correlator *EventCorrelator) *ScorecardSession {
+	return &ScorecardSession{
+		api:        api,
+		boardID:    boardID,
+		scene:      scene,
+		sources:    sources,
+		interval:   interval,
+		meeting:    meeting,
+		correlator: correlator,
+		results:    make(map[string][]string),
+		report:     ScorecardReport{Scene: scene.Title, Scorecards: len(sources)},
+	}
+}
+
+// PickResult returns a random result that can be flagged, or "" if there are none
+func (s *ScorecardSession) PickResult() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var resultIDs []string
+	for _, ids := range s.results {
+		resultIDs = append(resultIDs, ids...)
+	}
+	if len(resultIDs) == 0 {
+		return ""
+	}
+	return resultIDs[rand.Intn(len(resultIDs))]
+}
+
+// HasResults reports whether any result can be flagged
+func (s *ScorecardSession) HasResults() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ids := range s.results {
+		if len(ids) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordFlag stores one participant's flag
+func (s *ScorecardSession) RecordFlag(latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.flagFailures++
+		return
+	}
+	s.flags++
+	s.flagLatencies = append(s.flagLatencies, latency)
+}
+
+// setResults replaces the flaggable results of one attached scorecard
+func (s *ScorecardSession) setResults(sceneScorecardID string, resultIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[sceneScorecardID] = resultIDs
+}
+
+// Run moves the board to the scorecard scene, attaches every scorecard and
+// collects their data every interval until stopChan closes
+func (s *ScorecardSession) Run(stopChan <-chan bool) {
+	if err := s.api.ChangeScene(s.boardID, s.scene.ID); err != nil {
+		s.fail(fmt.Errorf("change to scorecard scene: %w", err))
+		return
+	}
+	s.correlator.RecordSentEvent("scene_changed", s.scene.ID, 0)
+	s.meeting.SetScene(s.scene)
+
+	if waitOrStop(scorecardSettleDelay, stopChan) {
+		return
+	}
+
+	for _, source := range s.sources {
+		attachment := ScorecardAttachment{Scorecard: source.scorecard.Name}
+		sceneScorecard, err := s.api.AttachScorecard(s.scene.ID, source.scorecard.ID)
+		if err != nil {
+			attachment.Error = err.Error()
+			PrintWarning("Scorecard", fmt.Sprintf("Failed to attach %q: %v", source.scorecard.Name, err))
+		} else {
+			attachment.EventID = s.correlator.RecordSentEvent("scorecard_attached", sceneScorecard.ID, 0)
+			s.attached = append(s.attached, attachedScorecard{source: source, sceneScorecardID: sceneScorecard.ID})
+		}
+		s.report.Attachments = append(s.report.Attachments, attachment)
+	}
+	if len(s.attached) == 0 {
+		s.fail(fmt.Errorf("no scorecard could be attached"))
+		return
+	}
+	PrintInfo("Scorecard", fmt.Sprintf("Attached %d scorecards to %q", len(s.attached), s.scene.Title))
+
+	for round := 1; ; round++ {
+		for _, attached := range s.attached {
+			collection := s.collect(round, attached)
+			s.mu.Lock()
+			s.report.Collections = append(s.report.Collections, collection)
+			s.mu.Unlock()
+		}
+		if waitOrStop(s.interval, stopChan) {
+			return
+		}
+	}
+}
+
+// collect sends fresh data for one attached scorecard and publishes its new
+// results for participants to flag
+func (s *ScorecardSession) collect(round int, attached attachedScorecard) ScorecardCollection {
+	collection := ScorecardCollection{Round: round, Scorecard: attached.source.scorecard.Name}
+
+	// Collection deletes the old results, so stop handing them out first
+	s.setResults(attached.sceneScorecardID, nil)
+
+	started := time.Now()
+	count, err := s.api.CollectScorecardData(attached.sceneScorecardID, scorecardData(attached.source.datasourceID))
+	collection.Latency = time.Since(started)
+	if err != nil {
+		collection.Error = err.Error()
+		PrintWarning("Scorecard", fmt.Sprintf("Round %d: collecting %q failed: %v", round, collection.Scorecard, err))
+		return collection
+	}
+	collection.EventID = s.correlator.RecordSentEvent("scorecard_data_collected", attached.sceneScorecardID, 0)
+	collection.Results = count
+
+	results, err := s.api.GetScorecardResults(attached.sceneScorecardID)
+	if err != nil {
+		PrintWarning("Scorecard", fmt.Sprintf("Round %d: fetching %q results failed: %v", round, collection.Scorecard, err))
+		return collection
+	}
+	resultIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		resultIDs = append(resultIDs, result.ID)
+	}
+	s.setResults(attached.sceneScorecardID, resultIDs)
+	return collection
+}
+
+// Report returns the session results, including delivery of each attach
+// and data collection broadcast
+func (s *ScorecardSession) Report() *ScorecardReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := s.report
+	report.Flags = s.flags
+	report.FlagFailures = s.flagFailures
+	report.FlagLatency = computeLatencyStats(s.flagLatencies)
+	report.Attachments = append([]ScorecardAttachment{}, s.report.Attachments...)
+	for i := range report.Attachments {
+		attachment := &report.Attachments[i]
+		if attachment.EventID != "" {
+			attachment.Expected, attachment.Received, attachment.FanOut = s.correlator.DeliveryFor(attachment.EventID)
+		}
+	}
+	report.Collections = append([]ScorecardCollection{}, s.report.Collections...)
+	for i := range report.Collections {
+		collection := &report.Collections[i]
+		if collection.EventID != "" {
+			collection.Expected, collection.Received, collection.FanOut = s.correlator.DeliveryFor(collection.EventID)
+		}
+	}
+	return &report
+}
+
+func (s *ScorecardSession) fail(err error) {
+	s.report.Error = err.Error()
+	PrintError("Scorecard", err.Error())
+}
@@ -5,38 +5,60 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
+// sseRecentMessages is how many recent messages each client remembers to
+// spot the server sending one twice. Messages stamped with the time they
+// were built are only identical if one was delivered twice; timer_update
+// carries no stamp, so it is not checked.
+const sseRecentMessages = 512
+
 // SSEClient handles Server-Sent Events connections
 type SSEClient struct {
 	baseURL       string
 	boardID       string
 	sessionCookie string
 	clientID      string
+	userID        int       // Simulated user this client belongs to
+	connectedAt   time.Time // When the connected event arrived
+	sceneID       string    // Board's current scene, which keys present mode updates
 	eventChan     chan ReceivedEvent
 	stopChan      chan bool
 	ctx           context.Context
 	cancel        context.CancelFunc
 	verbose       bool
+	schema        *SchemaValidator
+	isolation     *IsolationChecker
+	recent        map[uint64]bool // Hashes of the last sseRecentMessages messages
+	recentOrder   []uint64
+	mu            sync.Mutex
 }
 
-// NewSSEClient creates a new SSE client
-func NewSSEClient(baseURL, boardID, sessionCookie string, eventChan chan ReceivedEvent, verbose bool) *SSEClient {
+// NewSSEClient creates a new SSE client for userID, checking what it
+// receives with schema and isolation
+func NewSSEClient(baseURL, boardID, sessionCookie string, userID int, eventChan chan ReceivedEvent,
+	schema *SchemaValidator, isolation *IsolationChecker, verbose bool) *SSEClient {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &SSEClient{
 		baseURL:       baseURL,
 		boardID:       boardID,
 		sessionCookie: sessionCookie,
+		userID:        userID,
 		eventChan:     eventChan,
 		stopChan:      make(chan bool),
 		ctx:           ctx,
 		cancel:        cancel,
 		verbose:       verbose,
+		schema:        schema,
+		isolation:     isolation,
+		recent:        make(map[uint64]bool),
 	}
 }
 
@@ -143,6 +165,9 @@ func (s *SSEClient) handleEvent(eventType, data string) {
 			ClientID string `json:"clientId"`
 		}
 		if err := json.Unmarshal([]byte(data), &connData); err == nil {
+			s.mu.Lock()
+			s.connectedAt = time.Now()
+			s.mu.Unlock()
 			s.clientID = connData.ClientID
 			log.Printf("SSE connected, clientID: %s", s.clientID)
 		}
@@ -163,23 +188,212 @@ func (s *SSEClient) handleEvent(eventType, data string) {
 		eventType = dataType
 	}
 
+	// Check the payload as sent, before it is renamed below
+	s.schema.Validate(s.userID, eventType, eventData, data)
+
+	// Whatever another board's client should have got is reported and dropped
+	if s.isolation.CheckPayload(s.userID, s.boardID, eventType, eventData) {
+		return
+	}
+	if eventType != "presence_ping" && presenceEvents[eventType] {
+		s.isolation.CheckPresence(s.userID, s.boardID, eventType, parsePresence(eventData))
+	}
+
+	// The server announces new comments through update_presentation
+	if eventType == "update_presentation" {
+		if _, ok := eventData["new_comment"]; ok {
+			eventType = "comment_added"
+		}
+	}
+
 	// Extract card ID from various possible locations
 	cardID := s.extractCardID(eventType, eventData)
 
+	// Follow the board's scene so later present mode updates are keyed by it
+	if eventType == "scene_changed" && cardID != "" {
+		s.SetScene(cardID)
+	}
+
 	if cardID != "" {
-		// Send to event channel for correlation
-		select {
-		case s.eventChan <- ReceivedEvent{
+		received := ReceivedEvent{
 			Type:      eventType,
 			CardID:    cardID,
 			Timestamp: time.Now(),
-		}:
+		}
+		if stamp, stamped := eventData["timestamp"]; stamped {
+			received.Duplicate = s.seenBefore(data)
+			if ms, ok := stamp.(float64); ok {
+				received.Stamped = time.UnixMilli(int64(ms))
+			}
+		}
+		if eventType == "timer_update" {
+			received.Timer = parseTimerState(eventData)
+		}
+		if eventType == "update_presentation" {
+			received.Selection = parseSelection(eventData)
+			if id, ok := eventData["card_id"].(string); ok && id != "" {
+				received.Tags = []string{cardTag(id)}
+			}
+		}
+		if eventType == "columns_updated" {
+			received.Columns = parseColumnIDs(eventData)
+		}
+		if eventType != "presence_ping" && presenceEvents[eventType] {
+			received.Presence = parsePresence(eventData)
+		}
+		if eventType == "card_created" || eventType == "card_updated" {
+			received.Card = parseCard(eventData)
+			received.Tags = cardTags(received.Card)
+		}
+		if eventType == "vote_changed" {
+			if count, ok := eventData["vote_count"].(float64); ok {
+				voteCount := int(count)
+				received.VoteCount = &voteCount
+				received.Tags = []string{votesTag(&voteCount)}
+			}
+		}
+		if eventType == "all_votes_updated" {
+			received.AllVotes = parseAllVotes(eventData)
+		}
+		received.VotesCleared, _ = eventData["votes_cleared"].(bool)
+
+		// Send to event channel for correlation
+		select {
+		case s.eventChan <- received:
 		default:
 			// Channel full, skip
 		}
 	}
 }
 
+// seenBefore reports whether the same message arrived recently, and
+// remembers this one. Only readEvents calls it, so it needs no lock.
+func (s *SSEClient) seenBefore(data string) bool {
+	h := fnv.New64a()
+	h.Write([]byte(data))
+	sum := h.Sum64()
+	if s.recent[sum] {
+		return true
+	}
+	s.recent[sum] = true
+	s.recentOrder = append(s.recentOrder, sum)
+	if len(s.recentOrder) > sseRecentMessages {
+		delete(s.recent, s.recentOrder[0])
+		s.recentOrder = s.recentOrder[1:]
+	}
+	return false
+}
+
+// parseTimerState reads the timer carried in a timer_update's data field
+func parseTimerState(eventData map[string]interface{}) *TimerState {
+	raw, err := json.Marshal(eventData["data"])
+	if err != nil {
+		return nil
+	}
+	var timer TimerState
+	if err := json.Unmarshal(raw, &timer); err != nil {
+		return nil
+	}
+	return &timer
+}
+
+// parseCard reads the card carried by a card_created or card_updated
+func parseCard(eventData map[string]interface{}) *Card {
+	raw, err := json.Marshal(eventData["card"])
+	if err != nil {
+		return nil
+	}
+	var card Card
+	if err := json.Unmarshal(raw, &card); err != nil || card.ID == "" {
+		return nil
+	}
+	return &card
+}
+
+// cardTags returns the tags a card event may carry: the nonce in its
+// content and the column it is in
+func cardTags(card *Card) []string {
+	if card == nil {
+		return nil
+	}
+	tags := []string{columnTag(card.ColumnID)}
+	if nonce := contentNonce(card.Content); nonce != "" {
+		tags = append(tags, contentTag(nonce))
+	}
+	return tags
+}
+
+// parseAllVotes reads the per-card vote totals of an all_votes_updated
+func parseAllVotes(eventData map[string]interface{}) map[string]int {
+	byCard, ok := eventData["all_votes_by_card"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	votes := make(map[string]int, len(byCard))
+	for cardID, count := range byCard {
+		if count, ok := count.(float64); ok {
+			votes[cardID] = int(count)
+		}
+	}
+	return votes
+}
+
+// parseSelection returns the card an update_presentation leaves selected,
+// the way the web client reads it: present_mode_data's selected card when
+// the server built one, the card_id sent along otherwise. It returns nil for
+// updates that do not say.
+func parseSelection(eventData map[string]interface{}) *string {
+	if presentData, ok := eventData["present_mode_data"].(map[string]interface{}); ok {
+		selected := ""
+		if card, ok := presentData["selected_card"].(map[string]interface{}); ok {
+			selected, _ = card["id"].(string)
+		}
+		return &selected
+	}
+	if cardID, ok := eventData["card_id"]; ok {
+		selected, _ := cardID.(string)
+		return &selected
+	}
+	return nil
+}
+
+// parseColumnIDs returns the IDs of the columns listed by a columns_updated,
+// in board order
+func parseColumnIDs(eventData map[string]interface{}) []string {
+	columns, ok := eventData["columns"].([]interface{})
+	if !ok {
+		return nil
+	}
+	columnIDs := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column, ok := column.(map[string]interface{}); ok {
+			if id, ok := column["id"].(string); ok {
+				columnIDs = append(columnIDs, id)
+			}
+		}
+	}
+	return columnIDs
+}
+
+// parsePresence returns the IDs of the users listed in a presence event's
+// presence_data
+func parsePresence(eventData map[string]interface{}) []string {
+	presenceData, ok := eventData["presence_data"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	users, _ := presenceData["presence"].([]interface{})
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		if user, ok := user.(map[string]interface{}); ok {
+			if id, ok := user["userId"].(string); ok {
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+	return userIDs
+}
+
 // extractCardID extracts the card ID from event data based on event type
 func (s *SSEClient) extractCardID(eventType string, data map[string]interface{}) string {
 	switch eventType {
@@ -198,6 +412,11 @@ func (s *SSEClient) extractCardID(eventType string, data map[string]interface{})
 			return id
 		}
 
+	case "card_deleted":
+		if id, ok := data["card_id"].(string); ok {
+			return id
+		}
+
 	case "vote_changed":
 		// vote_changed uses card_id field
 		if id, ok := data["card_id"].(string); ok {
@@ -218,16 +437,130 @@ func (s *SSEClient) extractCardID(eventType string, data map[string]interface{})
 		if id, ok := data["cardId"].(string); ok {
 			return id
 		}
+
+	case "scene_created":
+		if scene, ok := data["scene"].(map[string]interface{}); ok {
+			if id, ok := scene["id"].(string); ok {
+				return id
+			}
+		}
+
+	case "scene_changed":
+		// scene_changed is keyed by the new scene's ID
+		if scene, ok := data["scene"].(map[string]interface{}); ok {
+			if id, ok := scene["id"].(string); ok {
+				return id
+			}
+		}
+
+	case "comment_added":
+		// comment_added is keyed by comment ID, whether it arrived as its own
+		// event or as an update_presentation carrying new_comment
+		for _, key := range []string{"comment", "new_comment"} {
+			if comment, ok := data[key].(map[string]interface{}); ok {
+				if id, ok := comment["id"].(string); ok {
+					return id
+				}
+			}
+		}
+
+	case "scene_updated":
+		// scene_updated names the scene whose content changed
+		if id, ok := data["scene_id"].(string); ok {
+			return id
+		}
+
+	case "update_presentation":
+		// Comment changes are keyed by comment ID. Everything else (card
+		// selection, notes) redraws the presented scene, and carries no
+		// scene ID, so it is keyed by the scene this client is showing.
+		if id, ok := data["comment_id"].(string); ok {
+			return id
+		}
+		if id, ok := data["deleted_comment_id"].(string); ok {
+			return id
+		}
+		return s.CurrentScene()
+
+	case "present_filter_changed":
+		if id, ok := data["scene_id"].(string); ok {
+			return id
+		}
+
+	case "quadrant_phase_changed", "quadrant_results_calculated":
+		// Quadrant phase changes are keyed by the quadrant scene
+		if id, ok := data["scene_id"].(string); ok {
+			return id
+		}
+
+	case "card_quadrant_adjusted", "quadrant_facilitator_position_updated":
+		// Facilitator adjustments in the results phase are keyed by card
+		if id, ok := data["card_id"].(string); ok {
+			return id
+		}
+
+	case "scorecard_attached":
+		// scorecard_attached carries the new scene scorecard
+		if sceneScorecard, ok := data["scene_scorecard"].(map[string]interface{}); ok {
+			if id, ok := sceneScorecard["id"].(string); ok {
+				return id
+			}
+		}
+
+	case "scorecard_data_collected":
+		if id, ok := data["scene_scorecard_id"].(string); ok {
+			return id
+		}
+
+	case "scorecard_result_flagged":
+		// Flags are keyed by result; the card they create is not broadcast separately
+		if id, ok := data["result_id"].(string); ok {
+			return id
+		}
+
+	case "user_joined", "user_left", "presence_update":
+		// Presence changes are keyed by the user they are about
+		if id, ok := data["user_id"].(string); ok {
+			return id
+		}
+
+	case "timer_update", "board_updated", "agreements_updated", "columns_updated", "all_votes_updated",
+		"voting_stats_updated", "presence_ping":
+		// Board-wide events are keyed by board ID
+		if id, ok := data["board_id"].(string); ok {
+			return id
+		}
 	}
 
 	return ""
 }
 
+// SetScene records the board's current scene
+func (s *SSEClient) SetScene(sceneID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sceneID = sceneID
+}
+
+// CurrentScene returns the board's current scene as this client last saw it
+func (s *SSEClient) CurrentScene() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sceneID
+}
+
 // GetClientID returns the client ID assigned by the server
 func (s *SSEClient) GetClientID() string {
 	return s.clientID
 }
 
+// ConnectedAt returns when the server confirmed the connection
+func (s *SSEClient) ConnectedAt() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connectedAt
+}
+
 // WaitForConnection waits for the SSE connection to establish and receive clientID
 func (s *SSEClient) WaitForConnection(timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -17,11 +18,78 @@ func PrintBanner(title string) {
 // PrintConfig prints the test configuration
 func PrintConfig(config *Config) {
 	fmt.Println("\nConfiguration:")
+	if config.ScenarioName != "" {
+		fmt.Printf("  Scenario: %s\n", config.ScenarioName)
+	}
 	fmt.Printf("  Base URL: %s\n", config.BaseURL)
+	fmt.Printf("  Template: %s\n", config.Template)
 	fmt.Printf("  Concurrent Users: %d\n", config.ConcurrentUsers)
+	if config.Boards > 1 {
+		fmt.Printf("  Boards: %d across %d series\n", config.Boards, seriesCount(config))
+	}
+	if config.BoardUsersMin > 0 {
+		fmt.Printf("  Users per Board: %d-%d\n", config.BoardUsersMin, config.BoardUsersMax)
+	}
+	if len(config.Stages) > 0 {
+		fmt.Printf("  Load Profile: %s\n", FormatStages(config.Stages))
+	}
 	fmt.Printf("  Test Duration: %v\n", config.TestDuration)
-	fmt.Printf("  Rate Limit: %d requests/min\n", config.RequestsPerMin)
+	if config.Pacing != PacingThink {
+		fmt.Printf("  Rate Limit: %d requests/min\n", config.RequestsPerMin)
+	}
+	fmt.Printf("  Pacing: %s\n", config.Pacing)
+	if config.Pacing == PacingThink {
+		fmt.Printf("  Think Time: %s\n", config.ThinkTime)
+	}
 	fmt.Printf("  Grace Period: %v\n", config.GracePeriod)
+	fmt.Printf("  Workload: %s\n", config.Workload)
+	if config.Workload == WorkloadLifecycle && config.SceneDuration > 0 {
+		fmt.Printf("  Scene Duration: %v\n", config.SceneDuration)
+	}
+	if config.Workload == WorkloadHealth {
+		fmt.Printf("  Health Survey: %s, %d rounds\n", config.HealthPreset, config.HealthRounds)
+	}
+	if config.Workload == WorkloadQuadrant {
+		fmt.Printf("  Quadrant: %d cards, %v input phases\n", config.QuadrantCards, config.QuadrantInput)
+	}
+	if config.Workload == WorkloadScorecard {
+		fmt.Printf("  Scorecards: %d, collected every %v\n", config.Scorecards, config.ScorecardInterval)
+	}
+	if config.Workload == WorkloadTimer {
+		fmt.Printf("  Timer: %v countdowns, %v tolerance\n", config.TimerDuration, config.TimerTolerance)
+	}
+	if config.Workload == WorkloadPresent {
+		fmt.Printf("  Present: %d cards, one every %v\n", config.PresentCards, config.PresentStep)
+	}
+	if config.Workload == WorkloadVoting {
+		fmt.Printf("  Voting: %d cards, %d votes each, %v rounds\n", config.VotingCards, config.VotingAllocation, config.VotingRound)
+	}
+	if config.ChurnInterval > 0 {
+		fmt.Printf("  Board Churn: every %v\n", config.ChurnInterval)
+	}
+	if config.PresencePoll > 0 {
+		fmt.Printf("  Presence Poll: every %v\n", config.PresencePoll)
+	}
+	if config.ConvergeInterval > 0 {
+		fmt.Printf("  Convergence Checks: every %v\n", config.ConvergeInterval)
+	}
+	if config.Workload == WorkloadNotes {
+		fmt.Printf("  Notes: %d contended cards, %v lock hold\n", config.NotesCards, config.NotesHold)
+	}
+	if len(config.Personas) > 1 {
+		fmt.Println("  Personas:")
+		for _, persona := range config.Personas {
+			count := "rest"
+			if persona.Count > 0 {
+				count = fmt.Sprintf("%d", persona.Count)
+			}
+			fmt.Printf("    %s (%s, %s users)", persona.Name, persona.Role, count)
+			if config.Pacing == PacingThink && persona.ThinkTime.Distribution != "" {
+				fmt.Printf(" think %s", persona.ThinkTime)
+			}
+			fmt.Println()
+		}
+	}
 	PrintBanner("")
 }
 
@@ -47,9 +115,22 @@ func PrintSetupProgress(step, message string) {
 }
 
 // PrintMonitoringStats prints real-time monitoring statistics
-func PrintMonitoringStats(elapsed time.Duration, activeUsers, totalUsers, eventsSent, eventsReceived int, rate float64) {
+func PrintMonitoringStats(elapsed time.Duration, activeUsers, targetUsers, eventsSent, eventsReceived int, rate float64) {
 	fmt.Printf("[%3ds] Active: %d/%d | Events sent: %d | Events received: %d | Rate: %.1f/s\n",
-		int(elapsed.Seconds()), activeUsers, totalUsers, eventsSent, eventsReceived, rate)
+		int(elapsed.Seconds()), activeUsers, targetUsers, eventsSent, eventsReceived, rate)
+}
+
+// PrintRateLimitHelp explains how to disable server-side rate limiting
+func PrintRateLimitHelp(title string) {
+	fmt.Println("\n" + strings.Repeat("═", 70))
+	fmt.Println("⚠️  " + title)
+	fmt.Println(strings.Repeat("═", 70))
+	fmt.Println("\nThe server is rate limiting requests. To run load tests, you need to")
+	fmt.Println("disable rate limiting by setting an environment variable:")
+	fmt.Println("\n  DISABLE_RATE_LIMITING=true npm run dev")
+	fmt.Println("\nOr if running in production mode:")
+	fmt.Println("\n  DISABLE_RATE_LIMITING=true node build")
+	fmt.Println("\n" + strings.Repeat("═", 70))
 }
 
 // PrintFinalReport prints the final test report
@@ -63,6 +144,9 @@ func PrintFinalReport(result *TestResult, config *Config) {
 	fmt.Printf("Connected Users: %d/%d (%.1f%%)\n",
 		result.ConnectedUsers, result.TotalUsers,
 		float64(result.ConnectedUsers)/float64(result.TotalUsers)*100.0)
+	if len(result.Stages) > 0 {
+		fmt.Printf("Peak Concurrent Users: %d\n", result.PeakUsers)
+	}
 	fmt.Printf("Total Events Sent: %d\n", result.EventsSent)
 
 	// Display expected and received events
@@ -83,6 +167,29 @@ func PrintFinalReport(result *TestResult, config *Config) {
 		if stats.Missed > 0 {
 			fmt.Printf("    ⚠️ Missed events: %d\n", stats.Missed)
 		}
+		if stats.AfterDelete > 0 {
+			fmt.Printf("    ⚠️ Accepted after the card was deleted (not expected): %d\n", stats.AfterDelete)
+		}
+	}
+
+	// Exactly who missed what
+	if len(result.MissedByUser) > 0 || result.Unexpected > 0 {
+		printMisses(result, config.Verbose)
+	}
+
+	// Duplicate, out-of-order and phantom deliveries
+	if anomalies := result.Anomalies; anomalies != nil && anomalies.Duplicates+anomalies.OutOfOrder+anomalies.Phantoms > 0 {
+		printAnomalies(anomalies)
+	}
+
+	// Events and presence lists that reached another board's clients
+	if isolation := result.Isolation; isolation != nil && (isolation.Boards > 1 || isolation.Leaked() > 0) {
+		printIsolation(isolation)
+	}
+
+	// Payloads that did not match their schema
+	if result.Schema != nil && result.Schema.Checked+len(result.Schema.Unknown) > 0 {
+		printSchema(result.Schema, config.Verbose)
 	}
 
 	// Latency statistics
@@ -96,6 +203,91 @@ func PrintFinalReport(result *TestResult, config *Config) {
 		fmt.Printf("  Max:  %v\n", result.LatencyStats.Max)
 	}
 
+	// API action latency
+	if result.ActionTimings != nil && result.ActionTimings.Count > 0 {
+		printActionTimings(result)
+	}
+
+	// Per-persona activity and delivery
+	if len(config.Personas) > 1 && len(result.ByPersona) > 0 {
+		printPersonaBreakdown(result)
+	}
+
+	// Per-user activity spread
+	if len(result.PerUser) > 0 {
+		printUserActivity(result, config.Verbose)
+	}
+
+	// Delivery by concurrency level (only meaningful when the population changed)
+	if len(result.Stages) > 0 && len(result.ByConcurrency) > 0 {
+		printConcurrencyBreakdown(result)
+	}
+
+	// Per-board delivery and how it spreads across the fleet
+	if result.Fleet != nil {
+		printFleet(result.Fleet, config.Verbose)
+	}
+
+	// Per-scene delivery and scene change propagation
+	if len(result.Scenes) > 0 {
+		printSceneBreakdown(result)
+	}
+
+	// Health survey bursts
+	if result.Health != nil {
+		printHealthSurvey(result.Health)
+	}
+
+	// Quadrant input and consensus cycles
+	if result.Quadrant != nil {
+		printQuadrant(result.Quadrant)
+	}
+
+	// Scorecard attach, collection and flagging
+	if result.Scorecards != nil {
+		printScorecards(result.Scorecards)
+	}
+
+	// Timer countdown agreement
+	if result.Timers != nil {
+		printTimers(result.Timers)
+	}
+
+	// Present mode selection fan-out
+	if result.Present != nil {
+		printPresent(result.Present)
+	}
+
+	// Vote allocation and totals
+	if result.Voting != nil {
+		printVoting(result.Voting)
+	}
+
+	// Board clones under load
+	if result.Clones != nil {
+		printClones(result.Clones)
+	}
+
+	// Presence against the users connected
+	if result.Presence != nil {
+		printPresence(result.Presence)
+	}
+
+	// Client board copies against the server
+	if result.Convergence != nil {
+		printConvergence(result.Convergence)
+	}
+
+	// Board admin churn
+	if result.Churn != nil {
+		printChurn(result.Churn)
+	}
+
+	// Notes lock contention
+	if result.NotesLocks != nil {
+		printNotesLocks(result.NotesLocks)
+	}
+
 	// Operation rate
 	result.MessageRate = float64(result.EventsSent) / result.Duration.Seconds()
 	operationRatePerMin := result.MessageRate * 60.0
@@ -114,7 +306,31 @@ func PrintFinalReport(result *TestResult, config *Config) {
 	// Final result
 	fmt.Println()
 	// deliveryRate already calculated above, just check the thresholds
-	if deliveryRate >= 99.9 {
+	if leaked := result.Isolation.Leaked(); leaked > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d events or presence lists leaked across boards)\n", leaked)
+	} else if duplicates := result.Health.DuplicateResponses(); duplicates > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d duplicate health responses stored)\n", duplicates)
+	} else if disagreements := result.Timers.Disagreements(); disagreements > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d client timer views stale or beyond %v tolerance)\n", disagreements, result.Timers.Tolerance)
+	} else if stale := result.Present.StaleClients(); stale > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d clients left showing a stale selection)\n", stale)
+	} else if violations := result.Voting.Violations(); violations > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d votes over allocation or cards with mismatched totals)\n", violations)
+	} else if result.Clones != nil && result.Clones.Mismatched > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d board clones differed from their source)\n", result.Clones.Mismatched)
+	} else if final := result.Convergence.Final(); final != nil && final.Divergent > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d of %d clients ended with a board that differs from the server's)\n",
+			final.Divergent, final.Clients)
+	} else if result.Presence != nil && result.Presence.Divergent() {
+		fmt.Printf("Result: ✗ FAIL (%d presence lists disagreed with the users connected)\n",
+			result.Presence.EventDivergent+result.Presence.PollDivergent)
+	} else if result.NotesLocks != nil && result.NotesLocks.Overlaps+result.NotesLocks.Interleaved > 0 {
+		fmt.Printf("Result: ✗ FAIL (notes lock violated: %d double grants, %d interleaved writes)\n",
+			result.NotesLocks.Overlaps, result.NotesLocks.Interleaved)
+	} else if result.Schema != nil && result.Schema.Invalid > 0 {
+		fmt.Printf("Result: ✗ FAIL (%d of %d SSE payloads did not match their schema)\n",
+			result.Schema.Invalid, result.Schema.Checked)
+	} else if deliveryRate >= 99.9 {
 		fmt.Printf("Result: ✓ PASS (%.2f%% delivery rate)\n", deliveryRate)
 	} else if deliveryRate >= 99.0 {
 		fmt.Printf("Result: ⚠ WARN (%.2f%% delivery rate)\n", deliveryRate)
@@ -125,6 +341,620 @@ func PrintFinalReport(result *TestResult, config *Config) {
 	PrintBanner("")
 }
 
+// printActionTimings prints API action latency. Under an open model the
+// latency from the intended start includes time spent waiting on a backlog,
+// so a slow server shows up here instead of as lower throughput.
+func printActionTimings(result *TestResult) {
+	timings := result.ActionTimings
+	fmt.Printf("\nAction Latency (%d actions, %d failed, pacing: %s):\n", timings.Count, timings.Failures, result.Pacing)
+	fmt.Printf("  %-22s %8s %8s %8s %8s %8s\n", "", "Mean", "P50", "P95", "P99", "Max")
+	rows := []struct {
+		label string
+		stats *LatencyStats
+	}{
+		{"From intended start:", timings.FromIntended},
+		{"From actual start:", timings.FromActual},
+		{"Start delay:", timings.StartDelay},
+	}
+	for _, row := range rows {
+		fmt.Printf("  %-22s %8s %8s %8s %8s %8s\n", row.label,
+			FormatDuration(row.stats.Mean), FormatDuration(row.stats.P50), FormatDuration(row.stats.P95),
+			FormatDuration(row.stats.P99), FormatDuration(row.stats.Max))
+	}
+}
+
+// printPersonaBreakdown prints one row per persona
+func printPersonaBreakdown(result *TestResult) {
+	fmt.Println("\nBy Persona:")
+	fmt.Printf("  %-16s %6s %8s %8s %8s %10s\n", "Persona", "Users", "Actions", "Failed", "Events", "Delivered")
+	for _, name := range PersonaNames(result.ByPersona) {
+		stats := result.ByPersona[name]
+		rate := 100.0
+		if stats.Expected > 0 {
+			rate = float64(stats.Received) / float64(stats.Expected) * 100.0
+		}
+		fmt.Printf("  %-16s %6d %8d %8d %8d %9.2f%%\n",
+			name, stats.Users, stats.Actions, stats.Failures, stats.Sent, rate)
+	}
+}
+
+// printUserActivity summarises how evenly actions were spread across users
+func printUserActivity(result *TestResult, verbose bool) {
+	counts := make([]int, len(result.PerUser))
+	idle := 0
+	for i, activity := range result.PerUser {
+		counts[i] = activity.Actions
+		if activity.Actions == 0 {
+			idle++
+		}
+	}
+	sort.Ints(counts)
+	percentile := func(p float64) int {
+		return counts[int(float64(len(counts)-1)*p)]
+	}
+
+	fmt.Printf("\nPer-User Activity (%d users):\n", len(result.PerUser))
+	fmt.Printf("  Actions per user: min %d | P50 %d | P90 %d | max %d\n",
+		counts[0], percentile(0.50), percentile(0.90), counts[len(counts)-1])
+	if idle > 0 {
+		fmt.Printf("  ⚠️ Users with no actions: %d\n", idle)
+	}
+
+	if verbose {
+		for _, activity := range result.PerUser {
+			fmt.Printf("  User %-4d %5d actions (%d failed)\n", activity.UserID, activity.Actions, activity.Failures)
+		}
+	}
+}
+
+// printMisses lists the users who missed events they were connected for,
+// most misses first, then the missed events themselves
+func printMisses(result *TestResult, verbose bool) {
+	total := 0
+	users := make([]int, 0, len(result.MissedByUser))
+	for userID, count := range result.MissedByUser {
+		total += count
+		users = append(users, userID)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if result.MissedByUser[users[i]] != result.MissedByUser[users[j]] {
+			return result.MissedByUser[users[i]] > result.MissedByUser[users[j]]
+		}
+		return users[i] < users[j]
+	})
+
+	fmt.Printf("\nMissed Deliveries (%d across %d users):\n", total, len(users))
+	shown := users
+	if !verbose && len(shown) > 10 {
+		shown = shown[:10]
+	}
+	for _, userID := range shown {
+		fmt.Printf("  User %-4d missed %d\n", userID, result.MissedByUser[userID])
+	}
+	if len(shown) < len(users) {
+		fmt.Printf("  ... and %d more users (-verbose lists all)\n", len(users)-len(shown))
+	}
+
+	misses := result.Misses
+	if !verbose && len(misses) > 20 {
+		misses = misses[:20]
+	}
+	for _, miss := range misses {
+		fmt.Printf("  %s %-22s card %s → user %d\n", miss.SentAt.Format("15:04:05.000"), miss.Type, miss.CardID, miss.UserID)
+	}
+	if len(misses) < total {
+		fmt.Printf("  ... %d missed deliveries not listed\n", total-len(misses))
+	}
+	if result.Unexpected > 0 {
+		fmt.Printf("  Receipts by users who connected after the send (not counted): %d\n", result.Unexpected)
+	}
+}
+
+// printAnomalies prints events clients received twice, out of causal order
+// or without anyone sending them, with examples of each
+func printAnomalies(report *AnomalyReport) {
+	fmt.Println("\nDelivery Anomalies:")
+	fmt.Printf("  Duplicates:   %d", report.Duplicates)
+	if report.Duplicates > 0 {
+		fmt.Printf(" (%d clients)", len(report.DuplicatesByUser))
+	}
+	fmt.Println()
+	fmt.Printf("  Out of order: %d\n", report.OutOfOrder)
+	fmt.Printf("  Phantoms:     %d (received, never matched a sent event)\n", report.Phantoms)
+	if report.Unchecked > 0 {
+		fmt.Printf("  Not checked:  %d (no send recorded for their type and card, or too many waiting)\n", report.Unchecked)
+	}
+
+	if len(report.DuplicatesByUser) > 0 {
+		users := make([]int, 0, len(report.DuplicatesByUser))
+		for userID := range report.DuplicatesByUser {
+			users = append(users, userID)
+		}
+		sort.Ints(users)
+		fmt.Println("  Duplicates by client:")
+		for _, userID := range users {
+			fmt.Printf("    User %-4d %d\n", userID, report.DuplicatesByUser[userID])
+		}
+	}
+	printAnomalyExamples("Duplicate", report.DuplicateExamples)
+	printAnomalyExamples("Out of order", report.OutOfOrderExamples)
+	printAnomalyExamples("Phantom", report.PhantomExamples)
+}
+
+func printAnomalyExamples(label string, examples []DeliveryAnomaly) {
+	for _, example := range examples {
+		line := fmt.Sprintf("  %s: %s %s card %s → user %d", label, example.At.Format("15:04:05.000"),
+			example.Type, example.CardID, example.UserID)
+		if example.Detail != "" {
+			line += " (" + example.Detail + ")"
+		}
+		fmt.Println(line)
+	}
+}
+
+// printIsolation prints what clients received from boards they did not join
+func printIsolation(report *IsolationReport) {
+	fmt.Println("\nBoard Isolation:")
+	fmt.Printf("  Boards:         %d\n", report.Boards)
+	fmt.Printf("  Payloads:       %d checked, %d for another board\n", report.Checked, report.Leaks)
+	fmt.Printf("  Presence lists: %d checked, %d showing another board's users\n", report.PresenceChecked, report.PresenceLeaks)
+
+	types := make([]string, 0, len(report.LeaksByType))
+	for eventType := range report.LeaksByType {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	for _, eventType := range types {
+		fmt.Printf("    %-30s %d\n", eventType, report.LeaksByType[eventType])
+	}
+	for _, leak := range report.Examples {
+		line := fmt.Sprintf("  Leak: %s %s → user %d on board %s, from board %s", leak.At.Format("15:04:05.000"),
+			leak.Type, leak.UserID, leak.Board, leak.From)
+		if leak.Detail != "" {
+			line += " (" + leak.Detail + ")"
+		}
+		fmt.Println(line)
+	}
+}
+
+// printSchema prints how many payloads matched their schema, the problems
+// found in those that did not, and a few of them in full
+func printSchema(report *SchemaReport, verbose bool) {
+	fmt.Println("\nPayload Schemas:")
+	fmt.Printf("  Checked: %d payloads of %d types\n", report.Checked, len(report.ByType))
+	fmt.Printf("  Invalid: %d\n", report.Invalid)
+
+	for _, problem := range report.sortedProblems() {
+		fmt.Printf("    %-60s %d\n", problem, report.Problems[problem])
+	}
+	for _, example := range report.Examples {
+		fmt.Printf("  Example: %s → user %d: %s\n", example.Type, example.UserID, strings.Join(example.Problems, "; "))
+		if verbose {
+			fmt.Printf("    %s\n", example.Payload)
+		}
+	}
+
+	if len(report.Unknown) > 0 {
+		types := make([]string, 0, len(report.Unknown))
+		for eventType := range report.Unknown {
+			types = append(types, eventType)
+		}
+		sort.Strings(types)
+		fmt.Println("  Types with no schema:")
+		for _, eventType := range types {
+			fmt.Printf("    %-30s %d\n", eventType, report.Unknown[eventType])
+		}
+	}
+}
+
+// printConcurrencyBreakdown groups delivery by connected-user count at send time
+func printConcurrencyBreakdown(result *TestResult) {
+	maxUsers := 0
+	for users := range result.ByConcurrency {
+		if users > maxUsers {
+			maxUsers = users
+		}
+	}
+	width := (maxUsers + 9) / 10
+	if width < 1 {
+		width = 1
+	}
+
+	buckets := make(map[int]*DeliveryStats)
+	for users, stats := range result.ByConcurrency {
+		bucket := users / width * width
+		merged, ok := buckets[bucket]
+		if !ok {
+			merged = &DeliveryStats{}
+			buckets[bucket] = merged
+		}
+		merged.Add(stats)
+	}
+
+	keys := make([]int, 0, len(buckets))
+	for bucket := range buckets {
+		keys = append(keys, bucket)
+	}
+	sort.Ints(keys)
+
+	fmt.Println("\nDelivery by Concurrent Users:")
+	for _, bucket := range keys {
+		stats := buckets[bucket]
+		rate := 100.0
+		if stats.Expected > 0 {
+			rate = float64(stats.Received) / float64(stats.Expected) * 100.0
+		}
+		latency := stats.LatencyStats()
+		fmt.Printf("  %4d-%-4d users: %6d sent → %.2f%% delivered | P50 %v | P95 %v | P99 %v\n",
+			bucket, bucket+width-1, stats.Sent, rate,
+			FormatDuration(latency.P50), FormatDuration(latency.P95), FormatDuration(latency.P99))
+	}
+}
+
+// printFleet prints how delivery and latency spread across boards, then the
+// worst boards, or every board with verbose
+func printFleet(report *FleetReport, verbose bool) {
+	fmt.Println("\nFleet:")
+	fmt.Printf("  Boards:    %d across %d series\n", len(report.Boards), report.Series)
+	fmt.Printf("  Users:     %d min | %d median | %d max per board\n", report.UsersMin, report.UsersMedian, report.UsersMax)
+	fmt.Printf("  Delivery:  %.2f%% median | %.2f%% worst 10%% | %.2f%% worst board\n",
+		report.RateMedian, report.RateP10, report.RateWorst)
+	printLatencySpread("Board P50", report.P50)
+	printLatencySpread("Board P95", report.P95)
+
+	boards := report.Boards
+	if !verbose && len(boards) > fleetWorstBoards {
+		boards = boards[:fleetWorstBoards]
+		fmt.Printf("  Worst %d boards (-verbose lists all):\n", fleetWorstBoards)
+	}
+	for _, board := range boards {
+		fmt.Printf("    #%-4d %s: %3d users | %6d sent → %.2f%% delivered | P50 %v | P95 %v\n",
+			board.Index, board.ID, board.Users, board.Sent, board.Rate,
+			FormatDuration(board.Latency.P50), FormatDuration(board.Latency.P95))
+	}
+}
+
+func printLatencySpread(label string, spread LatencySpread) {
+	fmt.Printf("  %s: %v median | %v P90 | %v P99 | %v max across boards\n", label,
+		FormatDuration(spread.Median), FormatDuration(spread.P90), FormatDuration(spread.P99), FormatDuration(spread.Max))
+}
+
+// printSceneBreakdown prints delivery for events sent in each scene, then how
+// each scene change fanned out and how long the board refetches it caused took
+func printSceneBreakdown(result *TestResult) {
+	fmt.Println("\nDelivery by Scene:")
+	for _, phase := range result.PhaseOrder {
+		stats := result.ByPhase[phase]
+		rate := 100.0
+		if stats.Expected > 0 {
+			rate = float64(stats.Received) / float64(stats.Expected) * 100.0
+		}
+		latency := stats.LatencyStats()
+		fmt.Printf("  %-16s %6d sent → %.2f%% delivered | P50 %v | P95 %v | P99 %v\n",
+			phase, stats.Sent, rate,
+			FormatDuration(latency.P50), FormatDuration(latency.P95), FormatDuration(latency.P99))
+	}
+
+	fmt.Println("\nScene Changes:")
+	fmt.Printf("  %-16s %-10s %10s %8s %11s %11s %7s\n", "Scene", "Mode", "Delivered", "Fan-out", "Refetch P50", "Refetch P95", "Failed")
+	for _, scene := range result.Scenes {
+		if scene.Error != "" {
+			fmt.Printf("  %-16s %-10s ✗ %s\n", scene.Title, scene.Mode, scene.Error)
+			continue
+		}
+		fmt.Printf("  %-16s %-10s %4d/%-5d %8s %11s %11s %7d\n",
+			scene.Title, scene.Mode, scene.Received, scene.Expected, FormatDuration(scene.FanOut),
+			FormatDuration(scene.Refetch.P50), FormatDuration(scene.Refetch.P95), scene.RefetchFailures)
+	}
+}
+
+// printHealthSurvey prints each answer burst: how long submissions took, how
+// long until each user saw its completion status and everyone's results,
+// and whether any duplicate responses were stored
+func printHealthSurvey(report *HealthReport) {
+	fmt.Printf("\nHealth Survey (%s, %d questions):\n", report.Preset, report.Questions)
+	if report.Error != "" {
+		fmt.Printf("  ✗ %s\n", report.Error)
+		return
+	}
+	fmt.Printf("  Preset scene_updated: %d/%d users, fan-out %v\n",
+		report.PresetReceived, report.PresetExpected, FormatDuration(report.PresetFanOut))
+	fmt.Printf("  %-5s %5s %7s %6s %11s %11s %14s %12s %10s\n",
+		"Round", "Users", "Answers", "Failed", "Submit P95", "Submit Max", "Completion P95", "Results P95", "Converged")
+	for _, round := range report.Rounds {
+		fmt.Printf("  %-5d %5d %7d %6d %11s %11s %14s %12s %4d/%-5d\n",
+			round.Round, round.Users, round.Answers, round.Failed,
+			FormatDuration(round.SubmitLatency.P95), FormatDuration(round.SubmitLatency.Max),
+			FormatDuration(round.CompletionLag.P95), FormatDuration(round.ResultsLag.P95),
+			round.Users-round.Unconverged, round.Users)
+		if round.DuplicateFailed > 0 {
+			fmt.Printf("    ⚠️ Double-submitted answers rejected: %d\n", round.DuplicateFailed)
+		}
+		if round.Duplicates > 0 {
+			fmt.Printf("    ✗ Duplicate responses stored: %d\n", round.Duplicates)
+		}
+	}
+}
+
+// printQuadrant prints how fast user drags landed and how each cycle's phase
+// change and consensus broadcasts fanned out
+func printQuadrant(report *QuadrantReport) {
+	fmt.Printf("\nQuadrant Positioning (%s, %d cards):\n", report.Scene, report.Cards)
+	if report.Error != "" {
+		fmt.Printf("  ✗ %s\n", report.Error)
+		return
+	}
+	fmt.Printf("  Positions: %d (%d failed) | P50 %v | P95 %v | P99 %v\n",
+		report.Positions, report.PositionFailures,
+		FormatDuration(report.PositionLatency.P50), FormatDuration(report.PositionLatency.P95), FormatDuration(report.PositionLatency.P99))
+	fmt.Printf("  %-5s %9s %9s %8s %13s %13s %13s %13s\n",
+		"Cycle", "Positions", "Consensus", "Adjusted", "Input Deliv.", "Input Fan-out", "Result Deliv.", "Result Fan-out")
+	for _, cycle := range report.Cycles {
+		if cycle.Error != "" {
+			fmt.Printf("  %-5d ✗ %s\n", cycle.Cycle, cycle.Error)
+			continue
+		}
+		fmt.Printf("  %-5d %9d %9d %4d/%-3d %6d/%-6d %13s %6d/%-6d %13s\n",
+			cycle.Cycle, cycle.Positions, cycle.Consensus, cycle.Adjusted, cycle.Adjusted+cycle.AdjustFailures,
+			cycle.PhaseReceived, cycle.PhaseExpected, FormatDuration(cycle.PhaseFanOut),
+			cycle.ResultsReceived, cycle.ResultsExpected, FormatDuration(cycle.ResultsFanOut))
+	}
+}
+
+// printScorecards prints how each attach and data collection fanned out and
+// how fast participants could flag results
+func printScorecards(report *ScorecardReport) {
+	fmt.Printf("\nScorecards (%s, %d scorecards):\n", report.Scene, report.Scorecards)
+	if report.Error != "" {
+		fmt.Printf("  ✗ %s\n", report.Error)
+	}
+	for _, attachment := range report.Attachments {
+		if attachment.Error != "" {
+			fmt.Printf("  Attach %-28s ✗ %s\n", attachment.Scorecard, attachment.Error)
+			continue
+		}
+		fmt.Printf("  Attach %-28s %d/%d users, fan-out %v\n",
+			attachment.Scorecard, attachment.Received, attachment.Expected, FormatDuration(attachment.FanOut))
+	}
+	fmt.Printf("  Flags: %d (%d failed) | P50 %v | P95 %v | P99 %v\n",
+		report.Flags, report.FlagFailures,
+		FormatDuration(report.FlagLatency.P50), FormatDuration(report.FlagLatency.P95), FormatDuration(report.FlagLatency.P99))
+	if len(report.Collections) == 0 {
+		return
+	}
+	fmt.Printf("  %-5s %-28s %7s %9s %10s %9s\n", "Round", "Scorecard", "Results", "Collect", "Delivered", "Fan-out")
+	for _, collection := range report.Collections {
+		if collection.Error != "" {
+			fmt.Printf("  %-5d %-28s ✗ %s\n", collection.Round, collection.Scorecard, collection.Error)
+			continue
+		}
+		fmt.Printf("  %-5d %-28s %7d %9s %4d/%-5d %9s\n",
+			collection.Round, collection.Scorecard, collection.Results, FormatDuration(collection.Latency),
+			collection.Received, collection.Expected, FormatDuration(collection.FanOut))
+	}
+}
+
+// printTimers prints, for each countdown, how many clients saw it start,
+// change and stop, and how far apart their countdowns ended
+func printTimers(report *TimerReport) {
+	fmt.Printf("\nTimer Agreement (%ds countdowns, %v tolerance):\n", report.Duration, report.Tolerance)
+	fmt.Printf("  %-5s %8s %10s %7s %7s %7s %5s %8s %9s %9s %7s\n",
+		"Round", "Duration", "Votes A/B", "Users", "Started", "Stopped", "Stale", "Spread", "Error P95", "Error Max", "Outside")
+	for _, round := range report.Rounds {
+		if round.Error != "" {
+			fmt.Printf("  %-5d ✗ %s\n", round.Round, round.Error)
+		}
+		duration := fmt.Sprintf("%ds", round.Duration)
+		if round.Extended {
+			duration += "+"
+		}
+		fmt.Printf("  %-5d %8s %4d/%-5d %7d %7d %7d %5d %8s %9s %9s %7d\n",
+			round.Round, duration, round.ExtendVotes, round.FinishVotes, round.Users, round.Started, round.Stopped,
+			round.Stale, FormatDuration(round.Spread), FormatDuration(round.EndError.P95), FormatDuration(round.EndError.Max),
+			round.OutOfTolerance)
+	}
+}
+
+// printPresent prints how each pass's filter change and card selections
+// fanned out and how many clients were left showing the wrong card
+func printPresent(report *PresentReport) {
+	fmt.Printf("\nPresent Mode (%s, %d cards, one every %v):\n", report.Scene, report.Cards, report.Step)
+	if report.Error != "" {
+		fmt.Printf("  ✗ %s\n", report.Error)
+		return
+	}
+	fmt.Printf("  Present data loads: %d (%d failed) | P50 %v | P95 %v | P99 %v\n",
+		report.Loads, report.LoadFailures,
+		FormatDuration(report.LoadLatency.P50), FormatDuration(report.LoadLatency.P95), FormatDuration(report.LoadLatency.P99))
+	fmt.Printf("  %-4s %5s %13s %14s %5s %13s %12s %12s %5s %6s\n",
+		"Pass", "Users", "Filter Deliv.", "Filter Fan-out", "Steps", "Select Deliv.", "Fan-out P95", "Fan-out Max", "Stale", "Missed")
+	for _, pass := range report.Passes {
+		if pass.Error != "" {
+			fmt.Printf("  %-4d ✗ %s\n", pass.Pass, pass.Error)
+			continue
+		}
+		fmt.Printf("  %-4d %5d %6d/%-6d %14s %5d %6d/%-6d %12s %12s %5d %6d\n",
+			pass.Pass, pass.Users, pass.FilterReceived, pass.FilterExpected, FormatDuration(pass.FilterFanOut),
+			pass.Steps, pass.StepReceived, pass.StepExpected,
+			FormatDuration(pass.StepFanOut.P95), FormatDuration(pass.StepFanOut.Max), pass.Stale, pass.Missed)
+	}
+}
+
+// printVoting prints each round's accepted and rejected votes, whether the
+// server's totals matched the accepted votes, and how the all_votes_updated
+// for each increase and clear fanned out
+func printVoting(report *VotingReport) {
+	fmt.Printf("\nVoting (%d cards, %d votes each, %v rounds):\n", report.Cards, report.Allocation, report.Round)
+	fmt.Printf("  %-5s %5s %8s %6s %8s %4s %10s %8s %14s %11s %13s\n",
+		"Round", "Alloc", "Accepted", "Server", "Rejected", "Over", "Unexpected", "Mismatch", "Increase Deliv", "Clear Deliv", "Clear Fan-out")
+	for _, round := range report.Rounds {
+		if round.Error != "" {
+			fmt.Printf("  %-5d ✗ %s\n", round.Round, round.Error)
+			continue
+		}
+		fmt.Printf("  %-5d %5d %8d %6d %8d %4d %10d %8d %6d/%-7d %5d/%-5d %13s\n",
+			round.Round, round.Allocation, round.Accepted, round.ServerTotal, round.Rejected, round.OverAllocated,
+			round.UnexpectedRejects, round.MismatchedCards, round.IncreaseReceived, round.IncreaseExpected,
+			round.ClearReceived, round.ClearExpected, FormatDuration(round.ClearFanOut))
+	}
+	if violations := report.Violations(); violations > 0 {
+		fmt.Printf("  ✗ Votes over allocation or mismatched card totals: %d\n", violations)
+	} else {
+		fmt.Println("  ✓ No votes over allocation and every card total matched")
+	}
+}
+
+// printClones prints how long clones took, whether source board events
+// slowed down while they ran, and how clones differed from their source
+func printClones(report *CloneReport) {
+	fmt.Printf("\nBoard Clones (%d, %d failed):\n", report.Attempts, report.Failures)
+	fmt.Printf("  Clone request: P50 %v | P95 %v | Max %v\n",
+		FormatDuration(report.Latency.P50), FormatDuration(report.Latency.P95), FormatDuration(report.Latency.Max))
+	printCloneWindow("Before clones", report.Before)
+	printCloneWindow("During clones", report.During)
+	for _, err := range report.Errors {
+		fmt.Printf("  ✗ %s\n", err)
+	}
+	if report.Mismatched > 0 {
+		fmt.Printf("  ✗ Clones differing from their source: %d\n", report.Mismatched)
+		for _, mismatch := range report.Mismatches {
+			fmt.Printf("    %s\n", mismatch)
+		}
+	} else if report.Attempts > report.Failures {
+		fmt.Printf("  ✓ Every clone matched its source's columns and scenes (%d source cards, none copied)\n", report.SourceCards)
+	}
+}
+
+// printCloneWindow prints delivery of the source board events sent in one window
+func printCloneWindow(label string, stats *DeliveryStats) {
+	rate := 100.0
+	if stats.Expected > 0 {
+		rate = float64(stats.Received) / float64(stats.Expected) * 100.0
+	}
+	latency := stats.LatencyStats()
+	fmt.Printf("  %-14s %5d events, %6.2f%% delivered | P50 %v | P95 %v | P99 %v\n",
+		label+":", stats.Sent, rate, FormatDuration(latency.P50), FormatDuration(latency.P95), FormatDuration(latency.P99))
+}
+
+// printPresence prints how often presence lists disagreed with the users
+// connected and how long joins and leaves took to reach every client
+func printPresence(report *PresenceReport) {
+	fmt.Printf("\nPresence (%v settle):\n", presenceSettle)
+	fmt.Printf("  SSE presence lists: %d checked, %d divergent (%d users missing, %d ghosts)\n",
+		report.EventChecks, report.EventDivergent, report.EventMissing, report.EventGhosts)
+	if report.PollInterval > 0 {
+		fmt.Printf("  Polls (every %v): %d checked, %d failed, %d divergent (%d users missing, %d ghosts)\n",
+			report.PollInterval, report.Polls, report.PollFailures, report.PollDivergent, report.PollMissing, report.PollGhosts)
+	}
+	fmt.Printf("  Joins:  %4d | converged P50 %v | P95 %v | Max %v | %d clients never saw one\n",
+		report.Joins, FormatDuration(report.JoinConvergence.P50), FormatDuration(report.JoinConvergence.P95),
+		FormatDuration(report.JoinConvergence.Max), report.JoinsUnseen)
+	fmt.Printf("  Leaves: %4d | converged P50 %v | P95 %v | Max %v | %d clients never saw one\n",
+		report.Leaves, FormatDuration(report.LeaveConvergence.P50), FormatDuration(report.LeaveConvergence.P95),
+		FormatDuration(report.LeaveConvergence.Max), report.LeavesUnseen)
+	fmt.Printf("  Presence pings answered: %d (%d failed)\n", report.Pongs, report.PongFailures)
+	if report.Divergent() {
+		fmt.Println("  ✗ Presence lists disagreed with the users connected")
+	} else {
+		fmt.Println("  ✓ Every presence list matched the users connected")
+	}
+}
+
+// printConvergence prints, for each checkpoint, how many clients' copies of
+// the board differed from the server's and in what way
+func printConvergence(report *ConvergenceReport) {
+	fmt.Printf("\nBoard Convergence (%v settle):\n", report.Settle)
+	if len(report.Checkpoints) == 0 {
+		fmt.Println("  No checks ran")
+		return
+	}
+	fmt.Printf("  %-8s %5s %5s %7s %9s %7s %5s %6s %5s %7s %5s %7s\n",
+		"At", "Cards", "Users", "Differ", "Missing", "Extra", "Col", "Group", "Text", "Votes", "Cols", "Settling")
+	for _, checkpoint := range report.Checkpoints {
+		label := FormatDuration(checkpoint.At)
+		if checkpoint.Final {
+			label = "final"
+		}
+		if checkpoint.Error != "" {
+			fmt.Printf("  %-8s %s\n", label, checkpoint.Error)
+			continue
+		}
+		fmt.Printf("  %-8s %5d %5d %7d %9d %7d %5d %6d %5d %7d %5d %7d\n",
+			label, checkpoint.Cards, checkpoint.Clients, checkpoint.Divergent, checkpoint.Missing, checkpoint.Extra,
+			checkpoint.WrongColumn, checkpoint.WrongGroup, checkpoint.WrongContent, checkpoint.WrongVotes,
+			checkpoint.WrongColumns, checkpoint.Settling)
+	}
+	final := report.Final()
+	if final == nil || final.Error != "" {
+		fmt.Println("  ⚠ Final check did not run")
+		return
+	}
+	for _, example := range final.Examples {
+		fmt.Printf("    %s\n", example)
+	}
+	if final.Divergent > 0 {
+		fmt.Printf("  ✗ %d of %d clients ended with a different board\n", final.Divergent, final.Clients)
+	} else {
+		fmt.Printf("  ✓ All %d clients ended with the server's board\n", final.Clients)
+	}
+}
+
+// printChurn prints how each board reconfiguration fared and which
+// participant actions failed against a column deleted under them
+func printChurn(report *ChurnReport) {
+	fmt.Printf("\nBoard Churn (every %v):\n", report.Interval)
+	fmt.Printf("  %-16s %5s %6s %9s %9s\n", "Operation", "Count", "Failed", "P50", "P95")
+	for _, operation := range report.Operations {
+		fmt.Printf("  %-16s %5d %6d %9s %9s\n",
+			operation.Name, operation.Count, operation.Failures,
+			FormatDuration(operation.Latency.P50), FormatDuration(operation.Latency.P95))
+	}
+	if len(report.StaleColumnFailures) == 0 {
+		fmt.Println("  Actions failed against a deleted column: 0")
+		return
+	}
+	actions := make([]string, 0, len(report.StaleColumnFailures))
+	for action := range report.StaleColumnFailures {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	fmt.Println("  Actions failed against a deleted column:")
+	for _, action := range actions {
+		fmt.Printf("    %-16s %d\n", action, report.StaleColumnFailures[action])
+	}
+}
+
+// printNotesLocks prints how users fared competing for notes locks and
+// whether the lock ever let two users in at once
+func printNotesLocks(stats *NotesLockStats) {
+	target := "random cards"
+	if stats.Cards > 0 {
+		target = fmt.Sprintf("%d contended cards", stats.Cards)
+	}
+	rejectionRate := 0.0
+	if stats.Attempts > 0 {
+		rejectionRate = float64(stats.Rejections) / float64(stats.Attempts) * 100.0
+	}
+
+	fmt.Printf("\nNotes Lock Contention (%s):\n", target)
+	fmt.Printf("  Lock requests: %d (%d acquired, %d rejected = %.1f%%, %d errors)\n",
+		stats.Attempts, stats.Acquired, stats.Rejections, rejectionRate, stats.Failures)
+	fmt.Printf("  Edits abandoned after %d attempts: %d\n", notesMaxAttempts, stats.Abandoned)
+	fmt.Printf("  Lock request latency: P50 %v | P95 %v | P99 %v\n",
+		FormatDuration(stats.RequestLatency.P50), FormatDuration(stats.RequestLatency.P95), FormatDuration(stats.RequestLatency.P99))
+	fmt.Printf("  Wait to acquire:      P50 %v | P95 %v | P99 %v\n",
+		FormatDuration(stats.AcquireWait.P50), FormatDuration(stats.AcquireWait.P95), FormatDuration(stats.AcquireWait.P99))
+	if stats.Overlaps > 0 {
+		fmt.Printf("  ✗ Lock granted while held by another user: %d\n", stats.Overlaps)
+	}
+	if stats.Interleaved > 0 {
+		fmt.Printf("  ✗ Another user's write landed under the lock: %d\n", stats.Interleaved)
+	}
+	if stats.Overlaps+stats.Interleaved == 0 {
+		fmt.Println("  ✓ No double grants or interleaved writes")
+	}
+}
+
 // FormatDuration formats a duration in a human-readable way
 func FormatDuration(d time.Duration) string {
 	if d < time.Millisecond {
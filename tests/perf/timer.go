@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timer session timing and voting
+const (
+	timerRoundGap     = 5 * time.Second // Pause after stopping a timer, while its stop broadcast lands
+	timerExtendShare  = 0.6             // Chance a participant votes for more time
+	timerChoiceExtend = "A"             // "More Time" in the timer's vote buttons
+	timerChoiceFinish = "B"
+)
+
+// timerObservation is one client's view of the timer from a timer_update
+type timerObservation struct {
+	at    time.Time
+	state TimerState
+}
+
+// projectedEnd is when the client's countdown reaches zero: like the web
+// client, it counts the received remaining seconds down from receipt
+func (o timerObservation) projectedEnd() time.Time {
+	return o.at.Add(time.Duration(o.state.Remaining) * time.Second)
+}
+
+// timerRound is one countdown started by the facilitator
+type timerRound struct {
+	number   int
+	id       string // Timer start timestamp, which votes reference
+	start    time.Time
+	duration int // Final length in seconds, including any extension
+	extended bool
+	users    int
+	votes    map[int]string           // user -> latest choice
+	latest   map[int]timerObservation // user -> latest view of this timer while active
+	stopped  map[int]bool             // users that saw the timer stop
+	err      error
+}
+
+// TimerSession runs countdowns the way a facilitator does: start a timer,
+// extend it at half time if most participants voted for more time, stop it
+// when it runs out. Every client's view of the timer is recorded so the
+// report can show how far apart their countdowns were.
+type TimerSession struct {
+	api        *APIClient
+	boardID    string
+	duration   int
+	tolerance  time.Duration
+	pool       *UserPool
+	correlator *EventCorrelator
+	rounds     []*timerRound
+	current    *timerRound
+	mu         sync.Mutex
+}
+
+// NewTimerSession creates a driver running countdowns of duration on the board
+func NewTimerSession(api *APIClient, boardID string, duration, tolerance time.Duration, pool *UserPool,
+	correlator *EventCorrelator) *TimerSession {
+	seconds := int(duration / time.Second)
+	if seconds < 2 {
+		seconds = 2
+	}
+	return &TimerSession{
+		api:        api,
+		boardID:    boardID,
+		duration:   seconds,
+		tolerance:  tolerance,
+		pool:       pool,
+		correlator: correlator,
+	}
+}
+
+// CurrentTimer returns the ID of the running timer, if any
+func (t *TimerSession) CurrentTimer() (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil || t.current.id == "" {
+		return "", false
+	}
+	return t.current.id, true
+}
+
+// PickChoice returns a vote, leaning towards more time
+func (t *TimerSession) PickChoice() string {
+	if rand.Float64() < timerExtendShare {
+		return timerChoiceExtend
+	}
+	return timerChoiceFinish
+}
+
+// RecordVote stores userID's accepted vote on timerID
+func (t *TimerSession) RecordVote(userID int, timerID, choice string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current != nil && t.current.id == timerID {
+		t.current.votes[userID] = choice
+	}
+}
+
+// RecordObservation stores the timer state userID received at receivedAt
+func (t *TimerSession) RecordObservation(userID int, receivedAt time.Time, state TimerState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil {
+		return
+	}
+	if !state.Active {
+		t.current.stopped[userID] = true
+		return
+	}
+	// The start broadcast can beat the start response, so keep views of a
+	// timer whose ID is not known yet and filter them in the report
+	if state.Start == "" || (t.current.id != "" && state.Start != t.current.id) {
+		return
+	}
+	if previous, ok := t.current.latest[userID]; !ok || !receivedAt.Before(previous.at) {
+		t.current.latest[userID] = timerObservation{at: receivedAt, state: state}
+	}
+}
+
+// Run starts, extends and stops one countdown after another until stopChan closes
+func (t *TimerSession) Run(stopChan <-chan bool) {
+	for number := 1; ; number++ {
+		round := t.runRound(number, stopChan)
+		if round == nil {
+			return
+		}
+		if round.err != nil {
+			PrintWarning("Timer", fmt.Sprintf("Round %d: %v", number, round.err))
+		} else {
+			PrintInfo("Timer", fmt.Sprintf("Round %d: %ds timer, extended=%t", number, round.duration, round.extended))
+		}
+		if waitOrStop(timerRoundGap, stopChan) {
+			return
+		}
+	}
+}
+
+// runRound runs one countdown, returning nil if stopChan closed before it started
+func (t *TimerSession) runRound(number int, stopChan <-chan bool) *timerRound {
+	select {
+	case <-stopChan:
+		return nil
+	default:
+	}
+
+	round := &timerRound{
+		number:   number,
+		duration: t.duration,
+		users:    t.connectedUsers(),
+		votes:    make(map[int]string),
+		latest:   make(map[int]timerObservation),
+		stopped:  make(map[int]bool),
+	}
+	t.mu.Lock()
+	t.rounds = append(t.rounds, round)
+	t.current = round
+	t.mu.Unlock()
+
+	timer, err := t.api.StartTimer(t.boardID, t.duration)
+	if err != nil {
+		round.err = fmt.Errorf("start timer: %w", err)
+		return round
+	}
+	t.correlator.RecordSentEvent("timer_update", t.boardID, 0)
+	start, err := time.Parse(time.RFC3339Nano, timer.Start)
+	if err != nil {
+		round.err = fmt.Errorf("parse timer start %q: %w", timer.Start, err)
+		return round
+	}
+	t.mu.Lock()
+	round.id = timer.Start
+	round.start = start
+	t.mu.Unlock()
+
+	// At half time, extend by half again if the room asked for more time
+	halfTime := time.Duration(t.duration) * time.Second / 2
+	if waitOrStop(time.Until(start.Add(halfTime)), stopChan) {
+		return round
+	}
+	extend, finish := t.tally(round)
+	if extend > finish {
+		addSeconds := t.duration / 2
+		if _, err := t.api.ExtendTimer(t.boardID, addSeconds); err != nil {
+			round.err = fmt.Errorf("extend timer: %w", err)
+		} else {
+			t.correlator.RecordSentEvent("timer_update", t.boardID, 0)
+			t.mu.Lock()
+			round.duration += addSeconds
+			round.extended = true
+			t.mu.Unlock()
+		}
+	}
+
+	end := start.Add(time.Duration(round.duration) * time.Second)
+	if waitOrStop(time.Until(end), stopChan) {
+		return round
+	}
+	if err := t.api.StopTimer(t.boardID); err != nil {
+		round.err = fmt.Errorf("stop timer: %w", err)
+		return round
+	}
+	t.correlator.RecordSentEvent("timer_update", t.boardID, 0)
+	return round
+}
+
+// tally counts the latest vote of every participant in round
+func (t *TimerSession) tally(round *timerRound) (extend, finish int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, choice := range round.votes {
+		if choice == timerChoiceExtend {
+			extend++
+		} else {
+			finish++
+		}
+	}
+	return extend, finish
+}
+
+// connectedUsers counts the users currently connected
+func (t *TimerSession) connectedUsers() int {
+	connected := 0
+	for _, u := range t.pool.Users() {
+		if u.IsConnected() {
+			connected++
+		}
+	}
+	return connected
+}
+
+// Report compares every client's final view of each countdown: how many saw
+// the final length, how far their projected end times spread, and how many
+// strayed from the median by more than the tolerance
+func (t *TimerSession) Report() *TimerReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := &TimerReport{Duration: t.duration, Tolerance: t.tolerance}
+	for _, round := range t.rounds {
+		roundReport := TimerRoundReport{
+			Round:    round.number,
+			Users:    round.users,
+			Duration: round.duration,
+			Extended: round.extended,
+			Stopped:  len(round.stopped),
+		}
+		for _, choice := range round.votes {
+			if choice == timerChoiceExtend {
+				roundReport.ExtendVotes++
+			} else {
+				roundReport.FinishVotes++
+			}
+		}
+		if round.err != nil {
+			roundReport.Error = round.err.Error()
+		}
+
+		var ends []time.Time
+		var offsets []time.Duration
+		truth := round.start.Add(time.Duration(round.duration) * time.Second)
+		for _, observation := range round.latest {
+			if observation.state.Start != round.id {
+				continue
+			}
+			roundReport.Started++
+			if observation.state.Duration() != round.duration {
+				roundReport.Stale++
+				continue
+			}
+			end := observation.projectedEnd()
+			ends = append(ends, end)
+			offset := end.Sub(truth)
+			if offset < 0 {
+				offset = -offset
+			}
+			offsets = append(offsets, offset)
+		}
+		roundReport.EndError = computeLatencyStats(offsets)
+
+		if len(ends) > 0 {
+			sort.Slice(ends, func(i, j int) bool { return ends[i].Before(ends[j]) })
+			roundReport.Spread = ends[len(ends)-1].Sub(ends[0])
+			median := ends[len(ends)/2]
+			for _, end := range ends {
+				offset := end.Sub(median)
+				if offset < 0 {
+					offset = -offset
+				}
+				if offset > t.tolerance {
+					roundReport.OutOfTolerance++
+				}
+			}
+		}
+		report.Rounds = append(report.Rounds, roundReport)
+	}
+	return report
+}
@@ -1,39 +1,164 @@
 package main
 
 import (
+	"math/bits"
 	"sync"
 	"time"
 )
 
 // Config holds all test configuration parameters
 type Config struct {
-	BaseURL         string
-	ConcurrentUsers int
-	TestDuration    time.Duration
-	RequestsPerMin  int
-	GracePeriod     time.Duration
-	AdminEmail      string
-	AdminPassword   string
-	Verbose         bool
-	Debug           bool
+	BaseURL           string
+	ConcurrentUsers   int
+	TestDuration      time.Duration
+	RequestsPerMin    int
+	GracePeriod       time.Duration
+	AdminEmail        string
+	AdminPassword     string
+	Verbose           bool
+	Debug             bool
+	ScenarioFile      string
+	ScenarioName      string
+	Template          string
+	SceneFlags        []string
+	ActionWeights     map[string]int
+	SettleDelay       time.Duration
+	WarmupDelay       time.Duration
+	SpawnInterval     time.Duration
+	Stages            []Stage
+	Pacing            string
+	ThinkTime         ThinkTime
+	Personas          []Persona
+	Workload          string
+	SceneDuration     time.Duration
+	NotesCards        int
+	NotesHold         time.Duration
+	HealthPreset      string
+	HealthRounds      int
+	QuadrantCards     int
+	QuadrantInput     time.Duration
+	Scorecards        int
+	ScorecardInterval time.Duration
+	TimerDuration     time.Duration
+	TimerTolerance    time.Duration
+	PresentCards      int
+	PresentStep       time.Duration
+	VotingCards       int
+	VotingRound       time.Duration
+	VotingAllocation  int
+	ChurnInterval     time.Duration
+	PresencePoll      time.Duration
+	ConvergeInterval  time.Duration
+	Boards            int
+	BoardUsersMin     int // Per-board user range; 0 spreads -users evenly
+	BoardUsersMax     int
+	BoardSizes        []int // Users planned for each board, sampled from the range
+	Series            int   // Series the boards are dealt across; 0 gives each its own
+}
+
+// RunContext bundles the collaborators shared by every simulated user in a run
+type RunContext struct {
+	Config      *Config
+	Correlator  *EventCorrelator
+	Timings     *ActionTimings
+	Meeting     *MeetingState // Set only when a workload drives the scene sequence
+	Notes       *NotesContention
+	Quadrant    *QuadrantSession  // Set only by the quadrant workload
+	Scorecards  *ScorecardSession // Set only by the scorecard workload
+	Timers      *TimerSession     // Set only by the timer workload
+	Present     *PresentSession   // Set only by the present workload
+	Voting      *VotingSession    // Set only by the voting workload
+	Clones      *CloneTracker
+	Presence    *PresenceTracker
+	Convergence *ConvergenceChecker
+	Churn       *ChurnDriver // Set only when board admin churn is enabled
+	Schema      *SchemaValidator
+	Isolation   *IsolationChecker
 }
 
 // SentEvent represents an event that was sent by a user action
 type SentEvent struct {
-	ID              string
-	Type            string
-	CardID          string
-	SenderID        int
-	Timestamp       time.Time
-	ConnectedUsers  int  // Number of users connected when event was sent
+	ID             string
+	Type           string
+	CardID         string
+	SenderID       int
+	Timestamp      time.Time
+	ConnectedUsers int         // Number of users in Expected
+	BoardID        string      // Board the sender was on
+	Expected       ReceiverSet // The users connected to that board when event was sent that it goes to
+	Tag            string      // Value in the payload that identifies this event; "" if none
+	Phase          string      // Scene the meeting was in when the event was sent
+	AfterDelete    bool        // Sent for a card whose deletion was already sent
+}
+
+// ReceiverSet is a set of simulated user IDs. Sets are shared between
+// events, so they are never changed in place; With and Without return a
+// new set.
+type ReceiverSet []uint64
+
+// Has reports whether userID is in the set
+func (s ReceiverSet) Has(userID int) bool {
+	word := userID / 64
+	return word < len(s) && s[word]&(1<<uint(userID%64)) != 0
+}
+
+// With returns a copy of the set including userID
+func (s ReceiverSet) With(userID int) ReceiverSet {
+	word := userID / 64
+	with := make(ReceiverSet, max(len(s), word+1))
+	copy(with, s)
+	with[word] |= 1 << uint(userID%64)
+	return with
+}
+
+// Without returns a copy of the set leaving out userID
+func (s ReceiverSet) Without(userID int) ReceiverSet {
+	without := append(ReceiverSet{}, s...)
+	if word := userID / 64; word < len(without) {
+		without[word] &^= 1 << uint(userID%64)
+	}
+	return without
+}
+
+// Len returns the number of users in the set
+func (s ReceiverSet) Len() int {
+	n := 0
+	for _, word := range s {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
+
+// IDs returns the users in the set in ascending order
+func (s ReceiverSet) IDs() []int {
+	var ids []int
+	for i, word := range s {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			ids = append(ids, i*64+bit)
+			word &^= 1 << uint(bit)
+		}
+	}
+	return ids
 }
 
 // ReceivedEvent represents an SSE event received by a user
 type ReceivedEvent struct {
-	Type       string
-	CardID     string
-	ReceiverID int
-	Timestamp  time.Time
+	Type         string
+	CardID       string
+	ReceiverID   int
+	Timestamp    time.Time
+	Timer        *TimerState    // Set for timer_update
+	Selection    *string        // Card left selected by an update_presentation; "" for none
+	Columns      []string       // Board column IDs in order, set for columns_updated
+	Presence     []string       // User IDs on the board, set for user_joined, user_left and presence_update
+	Card         *Card          // Card as sent, set for card_created and card_updated
+	VoteCount    *int           // Card's vote total, set for vote_changed
+	AllVotes     map[string]int // card -> votes, set for all_votes_updated
+	VotesCleared bool           // Set when the event reports all votes cleared
+	Tags         []string       // Payload values a sent event's Tag may match
+	Duplicate    bool           // The same message already reached this client
+	Stamped      time.Time      // When the server built the message, if it says
 }
 
 // UserContext holds the state for a simulated user
@@ -42,11 +167,14 @@ type UserContext struct {
 	Username      string
 	Email         string
 	Password      string
+	UserID        string // Server's ID for the user
 	SessionCookie string
 	ClientID      string
 	IsConnected   bool
 	CardIDs       []string
 	ColumnIDs     []string
+	Actions       int
+	FailedActions int
 	StopChan      chan bool
 	EventChan     chan ReceivedEvent
 	mu            sync.RWMutex
@@ -54,20 +182,49 @@ type UserContext struct {
 
 // BoardState represents the current state of a board
 type BoardState struct {
-	ID              string
-	Name            string
-	SeriesID        string
-	Status          string
-	CurrentSceneID  string
-	Columns         []Column
-	Scenes          []Scene
+	ID             string
+	Name           string
+	SeriesID       string
+	Status         string
+	CurrentSceneID string
+	Columns        []Column // Columns the current scene shows
+	AllColumns     []Column // Every column, including those the current scene hides
+	Scenes         []Scene
+	Cards          []Card
+}
+
+// CurrentUser is the signed-in user as /api/auth/me returns it
+type CurrentUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// PresenceUser is one entry in a board's presence list
+type PresenceUser struct {
+	UserID          string `json:"userId"`
+	CurrentActivity string `json:"currentActivity"`
+}
+
+// PresenceData is a board's presence list
+type PresenceData struct {
+	Presence            []PresenceUser `json:"presence"`
+	ConnectedUsersCount int            `json:"connected_users_count"`
+}
+
+// CloneSource is a board offered as the source of a clone
+type CloneSource struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	SeriesName string `json:"seriesName"`
 }
 
 // Column represents a board column
 type Column struct {
 	ID    string
-	Name  string
-	Order int
+	Name  string `json:"title"`
+	Order int    `json:"seq"`
 	Cards []Card
 }
 
@@ -77,9 +234,133 @@ type Card struct {
 	Content  string
 	ColumnID string
 	GroupID  string
+	Notes    string
 	Order    int
 }
 
+// Comment represents a comment on a card
+type Comment struct {
+	ID          string
+	CardID      string
+	UserID      string
+	Content     string
+	IsAgreement bool
+}
+
+// Agreement represents a board agreement, either standalone or a promoted comment
+type Agreement struct {
+	ID      string
+	Source  string // "agreement" or "comment"
+	Content string
+}
+
+// HealthQuestion represents a question in a survey scene
+type HealthQuestion struct {
+	ID           string
+	Question     string
+	QuestionType string
+	Seq          int
+}
+
+// HealthResult holds the aggregated answers to one health question
+type HealthResult struct {
+	Question       HealthQuestion
+	Average        float64
+	TotalResponses int
+	Distribution   map[string]int // rating -> count
+}
+
+// CompletionStatus reports how far a user is through a survey
+type CompletionStatus struct {
+	Completed         bool
+	TotalQuestions    int
+	AnsweredQuestions int
+}
+
+// Scorecard represents a series scorecard
+type Scorecard struct {
+	ID   string
+	Name string
+}
+
+// ScorecardDatasource represents one source of data feeding a scorecard
+type ScorecardDatasource struct {
+	ID          string
+	ScorecardID string
+	Name        string
+	SourceType  string
+}
+
+// SceneScorecard represents a scorecard attached to a scene
+type SceneScorecard struct {
+	ID          string
+	SceneID     string
+	ScorecardID string
+	ProcessedAt string
+}
+
+// ScorecardResult represents one item produced by a scorecard's rules
+type ScorecardResult struct {
+	ID           string
+	Section      string
+	Title        string
+	PrimaryValue string
+	Severity     string
+}
+
+// TimerState is the board timer as carried by timer_update and the timer endpoints
+type TimerState struct {
+	Start     string         `json:"timer_start"` // Also the timer's ID for votes
+	Passed    int            `json:"timer_passed"`
+	Remaining int            `json:"timer_remaining"`
+	Active    bool           `json:"active"`
+	Votes     map[string]int `json:"votes"`
+}
+
+// Duration returns the timer's total length in seconds
+func (t TimerState) Duration() int {
+	return t.Passed + t.Remaining
+}
+
+// PresentData is the board as present mode shows it to one user
+type PresentData struct {
+	SelectedCard    *Card  `json:"selected_card"`
+	VisibleCards    []Card `json:"visible_cards"`
+	CurrentUserRole string `json:"current_user_role"`
+}
+
+// UserVotes is one user's view of the board's votes
+type UserVotes struct {
+	VotesByCard    map[string]int // The user's own votes
+	AllVotesByCard map[string]int // Everyone's votes, only when the scene shows votes
+	Stats          VotingStats
+}
+
+// Cast returns how many votes the user has spent
+func (v *UserVotes) Cast() int {
+	total := 0
+	for _, votes := range v.VotesByCard {
+		total += votes
+	}
+	return total
+}
+
+// Remaining returns how many votes the user may still spend
+func (v *UserVotes) Remaining() int {
+	return v.Stats.MaxVotesPerUser - v.Cast()
+}
+
+// VotingStats is the board-wide vote tally as carried by the voting
+// endpoints and all_votes_updated
+type VotingStats struct {
+	TotalUsers      int `json:"totalUsers"`
+	ActiveUsers     int `json:"activeUsers"`
+	UsersWhoVoted   int `json:"usersWhoVoted"`
+	TotalVotesCast  int `json:"totalVotesCast"`
+	RemainingVotes  int `json:"remainingVotes"`
+	MaxVotesPerUser int `json:"maxVotesPerUser"`
+}
+
 // Scene represents a board scene
 type Scene struct {
 	ID              string
@@ -131,22 +412,201 @@ type TestResult struct {
 	Duration            time.Duration
 	ConnectedUsers      int
 	TotalUsers          int
+	PeakUsers           int
+	Stages              []Stage
+	Pacing              string
+	ActionTimings       *ActionTimingStats
+	PerUser             []UserActivity
 	EventsSent          int
 	EventsExpected      int
 	EventsReceived      int
 	ByType              map[string]*EventTypeStats
+	ByConcurrency       map[int]*DeliveryStats
+	ByPersona           map[string]*PersonaStats
+	ByPhase             map[string]*DeliveryStats
+	ByBoard             map[string]*DeliveryStats // board -> delivery of events sent on it
+	PhaseOrder          []string
+	Misses              []MissedDelivery // Up to correlatorMaxMisses, oldest first
+	MissedByUser        map[int]int      // user -> events they were connected for but never received
+	Unexpected          int              // Receipts by users who connected after the event was sent
+	Anomalies           *AnomalyReport
+	Schema              *SchemaReport
+	Isolation           *IsolationReport
+	Fleet               *FleetReport // Set when users were spread over several boards
+	Scenes              []SceneReport
+	NotesLocks          *NotesLockStats
+	Health              *HealthReport
+	Quadrant            *QuadrantReport
+	Scorecards          *ScorecardReport
+	Timers              *TimerReport
+	Present             *PresentReport
+	Voting              *VotingReport
+	Clones              *CloneReport
+	Presence            *PresenceReport
+	Convergence         *ConvergenceReport
+	Churn               *ChurnReport
 	LatencyStats        *LatencyStats
 	MessageRate         float64
 	ConnectionStability *ConnectionStats
 }
 
+// MissedDelivery is one event a connected user never received
+type MissedDelivery struct {
+	EventID string
+	Type    string
+	CardID  string
+	UserID  int
+	SentAt  time.Time
+}
+
+// DeliveryAnomaly is one event a client received wrongly
+type DeliveryAnomaly struct {
+	Type   string
+	CardID string
+	UserID int
+	At     time.Time
+	Detail string // How the event broke causal order, for out-of-order events
+}
+
+// AnomalyReport counts events delivered to a client twice, out of causal
+// order, or without anyone having sent them, with a few examples of each
+type AnomalyReport struct {
+	Duplicates         int
+	DuplicatesByUser   map[int]int
+	DuplicateExamples  []DeliveryAnomaly
+	OutOfOrder         int
+	OutOfOrderExamples []DeliveryAnomaly
+	Phantoms           int
+	PhantomExamples    []DeliveryAnomaly
+	Unchecked          int // Receipts of broadcasts no action records, or past the pending cap
+}
+
+// SchemaMismatch is one received payload that did not match its schema
+type SchemaMismatch struct {
+	Type     string
+	UserID   int
+	Problems []string
+	Payload  string // Raw payload, cut to schemaMaxPayload
+}
+
+// SchemaReport counts received payloads checked against their schema and
+// what was wrong with those that failed
+type SchemaReport struct {
+	Checked  int
+	Invalid  int
+	ByType   map[string]int // type -> payloads checked
+	Problems map[string]int // "type: problem" -> payloads with it
+	Examples []SchemaMismatch
+	Unknown  map[string]int // Types with no schema -> payloads received
+}
+
+// IsolationLeak is one payload or presence list a client received from a
+// board it did not join
+type IsolationLeak struct {
+	Type   string
+	UserID int    // Client that received it
+	Board  string // Board the client joined
+	From   string // Board the payload, or a listed user, belongs to
+	Detail string
+	At     time.Time
+}
+
+// IsolationReport counts what clients received from boards they did not join
+type IsolationReport struct {
+	Boards          int
+	Checked         int            // Payloads carrying a board_id
+	Leaks           int            // ...for another board
+	LeaksByType     map[string]int // type -> leaked payloads
+	PresenceChecked int            // Presence lists received
+	PresenceLeaks   int            // ...showing users from another board
+	Examples        []IsolationLeak
+}
+
+// BoardDelivery is delivery and latency on one board of a fleet
+type BoardDelivery struct {
+	Index    int // Position in setup order, from 1
+	ID       string
+	Users    int // Users who connected to the board
+	Sent     int
+	Expected int
+	Received int
+	Rate     float64
+	Latency  *LatencyStats
+}
+
+// LatencySpread is how one latency figure varies across boards
+type LatencySpread struct {
+	Median time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// FleetReport breaks delivery down by board and shows how it spreads across boards
+type FleetReport struct {
+	Series      int
+	Boards      []BoardDelivery // Lowest delivery rate first
+	UsersMin    int
+	UsersMedian int
+	UsersMax    int
+	RateMedian  float64 // Per-board delivery rates
+	RateP10     float64 // ...the rate 90% of boards reach
+	RateWorst   float64
+	P50         LatencySpread // Each board's median latency
+	P95         LatencySpread // Each board's 95th percentile latency
+}
+
+// Leaked returns how many payloads and presence lists crossed boards
+func (r *IsolationReport) Leaked() int {
+	if r == nil {
+		return 0
+	}
+	return r.Leaks + r.PresenceLeaks
+}
+
 // EventTypeStats holds statistics for a specific event type
 type EventTypeStats struct {
+	Sent        int
+	Expected    int
+	Received    int
+	Missed      int
+	Rate        float64
+	AfterDelete int // Sent for an already deleted card; left out of Expected and Received
+}
+
+// DeliveryStats holds delivery statistics for a group of sent events, such as
+// those sent at one connected-user count or during one scene
+type DeliveryStats struct {
 	Sent     int
 	Expected int
 	Received int
-	Missed   int
-	Rate     float64
+	Latency  *LatencyHistogram // nil until a receipt is recorded
+}
+
+// AddLatency records the latency of one receipt
+func (s *DeliveryStats) AddLatency(d time.Duration) {
+	if s.Latency == nil {
+		s.Latency = NewLatencyHistogram()
+	}
+	s.Latency.Add(d)
+}
+
+// Add totals other into s
+func (s *DeliveryStats) Add(other *DeliveryStats) {
+	s.Sent += other.Sent
+	s.Expected += other.Expected
+	s.Received += other.Received
+	if other.Latency != nil {
+		if s.Latency == nil {
+			s.Latency = NewLatencyHistogram()
+		}
+		s.Latency.Merge(other.Latency)
+	}
+}
+
+// LatencyStats returns the latency percentiles of the receipts
+func (s *DeliveryStats) LatencyStats() *LatencyStats {
+	return s.Latency.Stats()
 }
 
 // LatencyStats holds latency percentile statistics
@@ -160,6 +620,375 @@ type LatencyStats struct {
 	Count int
 }
 
+// ActionTimingStats holds API action latencies measured from the intended
+// start (which includes any scheduling backlog) and from the actual start
+type ActionTimingStats struct {
+	Count        int
+	Failures     int
+	FromIntended *LatencyStats
+	FromActual   *LatencyStats
+	StartDelay   *LatencyStats
+}
+
+// UserActivity holds how many actions one simulated user performed
+type UserActivity struct {
+	UserID   int
+	Persona  string
+	Actions  int
+	Failures int
+}
+
+// PersonaStats holds activity and delivery statistics for one persona
+type PersonaStats struct {
+	Users    int
+	Actions  int
+	Failures int
+	Sent     int
+	Expected int
+	Received int
+}
+
+// SceneReport holds how one lifecycle scene change propagated: delivery of
+// the scene_changed broadcast and the board refetches it triggered
+type SceneReport struct {
+	Title           string
+	Mode            string
+	Expected        int
+	Received        int
+	FanOut          time.Duration // Time until the last receiver saw scene_changed
+	Refetch         *LatencyStats
+	RefetchFailures int
+	Error           string
+}
+
+// NotesLockStats holds the results of users competing for card notes locks
+type NotesLockStats struct {
+	Cards          int // Contended cards; 0 means random board cards
+	Attempts       int
+	Rejections     int // Lock already held by another user
+	Acquired       int
+	Abandoned      int // Gave up after every retry was rejected
+	Failures       int
+	Overlaps       int // Lock granted while another user still held it
+	Interleaved    int // Notes changed under the lock holder
+	RequestLatency *LatencyStats
+	AcquireWait    *LatencyStats // First attempt until the lock was held
+}
+
+// HealthReport holds the results of the health survey workload
+type HealthReport struct {
+	Preset         string
+	Scene          string
+	Questions      int
+	PresetEventID  string
+	PresetExpected int // Users expected to receive the preset's scene_updated
+	PresetReceived int
+	PresetFanOut   time.Duration
+	Rounds         []HealthRoundReport
+	Error          string
+}
+
+// DuplicateResponses returns the duplicate responses stored across all rounds
+func (h *HealthReport) DuplicateResponses() int {
+	if h == nil {
+		return 0
+	}
+	total := 0
+	for _, round := range h.Rounds {
+		total += round.Duplicates
+	}
+	return total
+}
+
+// HealthRoundReport holds one synchronized burst of survey answers
+type HealthRoundReport struct {
+	Round           int
+	Users           int
+	Answers         int
+	Failed          int
+	DuplicateFailed int // Users whose double-submitted answer was rejected
+	Duplicates      int // Stored responses beyond one per user and question
+	Unconverged     int // Users who never saw everyone's answers
+	SubmitLatency   *LatencyStats
+	CompletionLag   *LatencyStats
+	ResultsLag      *LatencyStats
+}
+
+// QuadrantReport holds the results of the quadrant positioning workload
+type QuadrantReport struct {
+	Scene            string
+	Cards            int
+	Positions        int // User drags accepted across all input phases
+	PositionFailures int
+	PositionLatency  *LatencyStats
+	Cycles           []QuadrantCycle
+	Error            string
+}
+
+// QuadrantCycle holds one input, consensus and reset cycle
+type QuadrantCycle struct {
+	Cycle           int
+	Positions       int // User drags accepted during this input phase
+	Consensus       int // Cards that received a consensus position
+	Adjusted        int // Cards the facilitator moved in the results phase
+	AdjustFailures  int
+	PhaseEventID    string
+	PhaseExpected   int // Users expected to receive quadrant_phase_changed
+	PhaseReceived   int
+	PhaseFanOut     time.Duration
+	ResultsEventID  string
+	ResultsExpected int // Users expected to receive quadrant_results_calculated
+	ResultsReceived int
+	ResultsFanOut   time.Duration
+	Error           string
+}
+
+// ScorecardReport holds the results of the scorecard workload
+type ScorecardReport struct {
+	Scene        string
+	Scorecards   int
+	Attachments  []ScorecardAttachment
+	Collections  []ScorecardCollection
+	Flags        int // Results participants flagged for discussion
+	FlagFailures int
+	FlagLatency  *LatencyStats
+	Error        string
+}
+
+// ScorecardAttachment holds one scorecard being attached to the scene
+type ScorecardAttachment struct {
+	Scorecard string
+	EventID   string
+	Expected  int // Users expected to receive scorecard_attached
+	Received  int
+	FanOut    time.Duration
+	Error     string
+}
+
+// ScorecardCollection holds one data collection for one attached scorecard
+type ScorecardCollection struct {
+	Round     int
+	Scorecard string
+	Results   int
+	Latency   time.Duration // Collect request, including rule processing
+	EventID   string
+	Expected  int // Users expected to receive scorecard_data_collected
+	Received  int
+	FanOut    time.Duration
+	Error     string
+}
+
+// TimerReport holds the results of the timer workload
+type TimerReport struct {
+	Duration  int // Initial countdown length in seconds
+	Tolerance time.Duration
+	Rounds    []TimerRoundReport
+}
+
+// Disagreements returns the clients that missed a timer change or whose
+// countdown strayed beyond the tolerance, across all rounds
+func (t *TimerReport) Disagreements() int {
+	if t == nil {
+		return 0
+	}
+	total := 0
+	for _, round := range t.Rounds {
+		total += round.Stale + round.OutOfTolerance
+	}
+	return total
+}
+
+// TimerRoundReport holds one countdown and every client's view of it
+type TimerRoundReport struct {
+	Round          int
+	Users          int // Connected when the timer started
+	Duration       int // Final length in seconds
+	Extended       bool
+	ExtendVotes    int
+	FinishVotes    int
+	Started        int           // Clients that saw the timer running
+	Stopped        int           // Clients that saw the timer stop
+	Stale          int           // Clients whose last view missed the extension
+	Spread         time.Duration // Earliest to latest projected end across clients
+	EndError       *LatencyStats // Projected end against the server's end time
+	OutOfTolerance int           // Clients whose projected end strayed from the median beyond the tolerance
+	Error          string
+}
+
+// PresentReport holds the results of the present mode workload
+type PresentReport struct {
+	Scene        string
+	Cards        int
+	Step         time.Duration
+	Passes       []PresentPass
+	Loads        int // Present data fetches by participants
+	LoadFailures int
+	LoadLatency  *LatencyStats
+	Error        string
+}
+
+// StaleClients returns the clients left showing the wrong card after a
+// pass, across all passes
+func (p *PresentReport) StaleClients() int {
+	if p == nil {
+		return 0
+	}
+	total := 0
+	for _, pass := range p.Passes {
+		total += pass.Stale
+	}
+	return total
+}
+
+// PresentPass holds one walk through every card of the present scene
+type PresentPass struct {
+	Pass           int
+	Users          int // Connected when the pass was checked
+	FilterExpected int // Users expected to receive present_filter_changed
+	FilterReceived int
+	FilterFanOut   time.Duration
+	Steps          int // Cards selected
+	StepExpected   int // update_presentation deliveries expected across all steps
+	StepReceived   int
+	StepFanOut     *LatencyStats // Slowest delivery of each step
+	Stale          int           // Clients left showing another card once the pass settled
+	Missed         int           // Clients that saw no selection during the pass
+	Error          string
+}
+
+// VotingReport holds the results of the voting workload
+type VotingReport struct {
+	Cards      int
+	Allocation int // Votes per user at the start of each round
+	Round      time.Duration
+	Rounds     []VotingRound
+}
+
+// Violations returns the votes accepted beyond the allocation and the cards
+// whose final totals disagreed with the accepted votes, across all rounds
+func (v *VotingReport) Violations() int {
+	if v == nil {
+		return 0
+	}
+	total := 0
+	for _, round := range v.Rounds {
+		total += round.OverAllocated + round.MismatchedCards
+	}
+	return total
+}
+
+// VotingRound holds one round of voting between clears
+type VotingRound struct {
+	Round             int
+	Allocation        int // Votes per user by the end of the round
+	Accepted          int // Votes the server accepted
+	Rejected          int // Votes beyond the allocation the server turned away
+	OverAllocated     int // Votes beyond the allocation the server accepted
+	UnexpectedRejects int // Votes within the allocation the server turned away
+	ServerTotal       int // totalVotesCast before the clear
+	MismatchedCards   int // Cards whose server total differed from the accepted votes
+	IncreaseExpected  int // Users expected to receive all_votes_updated for the increase
+	IncreaseReceived  int
+	ClearExpected     int // Users expected to receive all_votes_updated for the clear
+	ClearReceived     int
+	ClearFanOut       time.Duration
+	Error             string
+}
+
+// CloneReport holds the results of facilitators cloning the board under load
+type CloneReport struct {
+	Attempts    int
+	Failures    int
+	Errors      []string       // The first few failures
+	Latency     *LatencyStats  // Clone request, for successful clones
+	SourceCards int            // Cards on the source across all snapshots; clones copy none
+	Mismatched  int            // Clones that differed from their source snapshot
+	Mismatches  []string       // The first few differences
+	Before      *DeliveryStats // Source board events sent in the window before each clone, as long as the clone
+	During      *DeliveryStats // Source board events sent while each clone ran
+}
+
+// PresenceReport holds the results of checking presence against the model
+type PresenceReport struct {
+	PollInterval     time.Duration
+	EventChecks      int // Presence lists received over SSE
+	EventDivergent   int // ...that disagreed with the model
+	EventMissing     int // Connected users left out of those lists
+	EventGhosts      int // Departed users still in those lists
+	Polls            int
+	PollFailures     int
+	PollDivergent    int
+	PollMissing      int
+	PollGhosts       int
+	Joins            int
+	JoinsUnseen      int           // Clients that never saw a join
+	JoinConvergence  *LatencyStats // Join to each client seeing it
+	Leaves           int
+	LeavesUnseen     int           // Clients that never saw a leave
+	LeaveConvergence *LatencyStats // Leave to each client seeing it
+	Pongs            int
+	PongFailures     int
+}
+
+// Divergent reports whether any presence list disagreed with the model
+func (r *PresenceReport) Divergent() bool {
+	return r.EventDivergent > 0 || r.PollDivergent > 0
+}
+
+// ConvergenceReport holds the results of diffing client replicas against the board
+type ConvergenceReport struct {
+	Interval    time.Duration
+	Settle      time.Duration
+	Checkpoints []ConvergenceCheckpoint
+}
+
+// Final returns the end-of-run checkpoint, or nil if it did not run
+func (r *ConvergenceReport) Final() *ConvergenceCheckpoint {
+	if r == nil {
+		return nil
+	}
+	for i := range r.Checkpoints {
+		if r.Checkpoints[i].Final {
+			return &r.Checkpoints[i]
+		}
+	}
+	return nil
+}
+
+// ConvergenceCheckpoint holds one comparison of every client with the board
+type ConvergenceCheckpoint struct {
+	At           time.Duration // Since the checker started
+	Final        bool
+	Error        string
+	Cards        int // Cards unchanged between the two reads, which clients are held to
+	Settling     int // Cards still changing, left out
+	Clients      int
+	Divergent    int // Clients with any difference
+	Missing      int // Cards on the board a client lacks
+	Extra        int // Cards a client holds that are gone from the board
+	WrongColumn  int
+	WrongGroup   int
+	WrongContent int
+	WrongVotes   int
+	WrongColumns int // Clients whose column list differs
+	Examples     []string
+}
+
+// ChurnReport holds the results of the board admin churn actor
+type ChurnReport struct {
+	Interval            time.Duration
+	Operations          []ChurnOperation
+	StaleColumnFailures map[string]int // action -> participant failures against a deleted column
+}
+
+// ChurnOperation holds the outcome of one kind of board reconfiguration
+type ChurnOperation struct {
+	Name     string
+	Count    int
+	Failures int
+	Latency  *LatencyStats
+}
+
 // ConnectionStats holds connection stability metrics
 type ConnectionStats struct {
 	Disconnections int
@@ -174,12 +1003,50 @@ func (u *UserContext) AddCardID(cardID string) {
 	u.CardIDs = append(u.CardIDs, cardID)
 }
 
+func (u *UserContext) RemoveCardID(cardID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for i, id := range u.CardIDs {
+		if id == cardID {
+			u.CardIDs = append(u.CardIDs[:i], u.CardIDs[i+1:]...)
+			return
+		}
+	}
+}
+
 func (u *UserContext) GetCardIDs() []string {
 	u.mu.RLock()
 	defer u.mu.RUnlock()
 	return append([]string{}, u.CardIDs...)
 }
 
+func (u *UserContext) SetColumnIDs(columnIDs []string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.ColumnIDs = columnIDs
+}
+
+func (u *UserContext) GetColumnIDs() []string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return append([]string{}, u.ColumnIDs...)
+}
+
+func (u *UserContext) RecordAction(err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.Actions++
+	if err != nil {
+		u.FailedActions++
+	}
+}
+
+func (u *UserContext) ActionCounts() (actions, failures int) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.Actions, u.FailedActions
+}
+
 func (u *UserContext) SetConnected(connected bool) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -3,21 +3,45 @@ package main
 import (
 	"fmt"
 	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // UserSimulator simulates a single user's behavior
 type UserSimulator struct {
-	ctx        *UserContext
-	api        *APIClient
-	sse        *SSEClient
-	correlator *EventCorrelator
-	config     *Config
-	boardID    string
+	ctx         *UserContext
+	api         *APIClient
+	sse         *SSEClient
+	correlator  *EventCorrelator
+	config      *Config
+	timings     *ActionTimings
+	meeting     *MeetingState
+	notes       *NotesContention
+	quadrant    *QuadrantSession
+	scorecards  *ScorecardSession
+	timers      *TimerSession
+	present     *PresentSession
+	voting      *VotingSession
+	clones      *CloneTracker
+	presence    *PresenceTracker
+	convergence *ConvergenceChecker
+	replica     *BoardReplica
+	order       *deliveryOrder
+	churn       *ChurnDriver
+	schema      *SchemaValidator
+	isolation   *IsolationChecker
+	persona     Persona
+	thinkTime   ThinkTime
+	boardID     string
+	nonces      atomic.Int64
+	voteMu      sync.Mutex // Serialises reading the allocation and spending from it
 }
 
 // NewUserSimulator creates a new user simulator
-func NewUserSimulator(userID int, boardID string, columnIDs []string, correlator *EventCorrelator, config *Config) *UserSimulator {
+func NewUserSimulator(userID int, boardID string, columnIDs []string, persona Persona, run *RunContext) *UserSimulator {
+	config := run.Config
 	timestamp := time.Now().UnixNano() % 1000000
 	username := fmt.Sprintf("testuser%d_%d", userID, timestamp)
 
@@ -32,17 +56,38 @@ func NewUserSimulator(userID int, boardID string, columnIDs []string, correlator
 		EventChan: make(chan ReceivedEvent, 100),
 	}
 
+	thinkTime := persona.ThinkTime
+	if thinkTime.Distribution == "" {
+		thinkTime = config.ThinkTime
+	}
+
 	return &UserSimulator{
-		ctx:        ctx,
-		api:        NewAPIClient(config.BaseURL, config.Debug),
-		correlator: correlator,
-		config:     config,
-		boardID:    boardID,
+		ctx:         ctx,
+		api:         NewAPIClient(config.BaseURL, config.Debug),
+		correlator:  run.Correlator,
+		config:      config,
+		timings:     run.Timings,
+		meeting:     run.Meeting,
+		notes:       run.Notes,
+		quadrant:    run.Quadrant,
+		scorecards:  run.Scorecards,
+		timers:      run.Timers,
+		present:     run.Present,
+		voting:      run.Voting,
+		clones:      run.Clones,
+		presence:    run.Presence,
+		convergence: run.Convergence,
+		churn:       run.Churn,
+		schema:      run.Schema,
+		isolation:   run.Isolation,
+		persona:     persona,
+		thinkTime:   thinkTime,
+		boardID:     boardID,
 	}
 }
 
 // Setup authenticates the user and establishes SSE connection
-func (u *UserSimulator) Setup() error {
+func (u *UserSimulator) Setup() (err error) {
 	// Try to register
 	cookie, err := u.api.Register(u.ctx.Email, u.ctx.Username, u.ctx.Password)
 	if err != nil {
@@ -55,12 +100,30 @@ func (u *UserSimulator) Setup() error {
 
 	u.ctx.SessionCookie = cookie
 
+	// Presence is keyed by the server's user ID
+	user, err := u.api.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("load user failed: %w", err)
+	}
+	u.ctx.UserID = user.ID
+	u.isolation.RegisterUser(user.ID, u.boardID)
+
 	// Establish SSE connection
-	u.sse = NewSSEClient(u.config.BaseURL, u.boardID, cookie, u.ctx.EventChan, u.config.Verbose)
+	u.sse = NewSSEClient(u.config.BaseURL, u.boardID, cookie, u.ctx.ID, u.ctx.EventChan, u.schema, u.isolation,
+		u.config.Verbose)
 	if err := u.sse.Connect(); err != nil {
 		return fmt.Errorf("SSE connection failed: %w", err)
 	}
 
+	// A user that fails setup never starts or stops, so drop its connection
+	// rather than leave it present on the board
+	defer func() {
+		if err != nil {
+			u.sse.Close()
+			u.sse = nil
+		}
+	}()
+
 	// Wait for connection to establish and get clientID
 	if err := u.sse.WaitForConnection(10 * time.Second); err != nil {
 		return fmt.Errorf("SSE connection timeout: %w", err)
@@ -69,19 +132,51 @@ func (u *UserSimulator) Setup() error {
 	u.ctx.ClientID = u.sse.GetClientID()
 
 	// Join the board
-	if err := u.api.JoinBoard(u.ctx.ClientID, u.boardID, u.ctx.Username); err != nil {
+	if err := u.api.JoinBoard(u.ctx.ClientID, u.boardID, u.ctx.UserID); err != nil {
 		return fmt.Errorf("join board failed: %w", err)
 	}
 
+	// Present mode updates carry no scene, so start from the board's current
+	// one, and take the columns as they are now rather than at spawn
+	board, err := u.api.GetBoard(u.boardID)
+	if err != nil {
+		return fmt.Errorf("load board failed: %w", err)
+	}
+	u.sse.SetScene(board.CurrentSceneID)
+	u.order = newDeliveryOrder(board)
+	columnIDs := make([]string, 0, len(board.Columns))
+	for _, column := range board.Columns {
+		columnIDs = append(columnIDs, column.ID)
+	}
+	u.ctx.SetColumnIDs(columnIDs)
+
+	// Like the web client, build the board from this snapshot and the stream
+	if u.convergence != nil {
+		votes, err := u.api.GetUserVotes(u.boardID)
+		if err != nil {
+			return fmt.Errorf("load votes failed: %w", err)
+		}
+		u.replica = NewBoardReplica(board, votes.AllVotesByCard)
+	}
+
+	if u.presence != nil {
+		u.presence.Join(u.ctx.UserID, u.ctx.ID, u.sse.ConnectedAt())
+	}
 	u.ctx.SetConnected(true)
 	return nil
 }
 
-// Start begins the user's activity simulation
+// Start begins the user's activity simulation. With an open-model pacing
+// rateLimiter is nil and actions are dispatched by the ArrivalScheduler instead.
 func (u *UserSimulator) Start(stopChan chan bool, rateLimiter <-chan time.Time) {
 	// Start listening for SSE events
 	go u.listenForEvents()
 
+	if u.config.Pacing == PacingThink {
+		u.thinkLoop(stopChan)
+		return
+	}
+
 	// Start activity loop - wait for rate limiter ticks
 	for {
 		select {
@@ -89,14 +184,44 @@ func (u *UserSimulator) Start(stopChan chan bool, rateLimiter <-chan time.Time)
 			return
 		case <-u.ctx.StopChan:
 			return
-		case <-rateLimiter:
+		case tick := <-rateLimiter:
 			if u.ctx.Connected() {
-				u.performRandomAction()
+				u.act(tick)
 			}
 		}
 	}
 }
 
+// thinkLoop paces this user independently: pause for a sampled think time, act, repeat
+func (u *UserSimulator) thinkLoop(stopChan chan bool) {
+	// Random initial offset so users with a fixed think time don't act in lockstep
+	offset := time.Duration(rand.Float64() * float64(u.thinkTime.Sample()))
+	next := time.Now().Add(offset)
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-u.ctx.StopChan:
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		if u.ctx.Connected() {
+			u.act(next)
+		}
+		next = time.Now().Add(u.thinkTime.Sample())
+	}
+}
+
+// act performs one random action and records its timing and outcome
+func (u *UserSimulator) act(intended time.Time) {
+	started := time.Now()
+	err := u.performRandomAction()
+	u.timings.Record(intended, started, time.Now(), err)
+	u.ctx.RecordAction(err)
+}
+
 // listenForEvents processes incoming SSE events
 func (u *UserSimulator) listenForEvents() {
 	for {
@@ -106,58 +231,302 @@ func (u *UserSimulator) listenForEvents() {
 		case event := <-u.ctx.EventChan:
 			// Add receiver ID
 			event.ReceiverID = u.ctx.ID
+
+			if u.replica != nil {
+				u.replica.Apply(event)
+			}
+
+			// A second copy of a message is counted but not correlated again
+			if event.Duplicate {
+				u.correlator.RecordDuplicate(event)
+				continue
+			}
+			if detail := u.order.check(event); detail != "" {
+				u.correlator.RecordOutOfOrder(event, detail)
+			}
+
+			// Presence is checked against the tracker's model rather than
+			// correlated, since no action sends it
+			if presenceEvents[event.Type] {
+				u.handlePresence(event)
+				continue
+			}
+
 			// Note: Users DO receive their own events via SSE, but we don't count them
 			// in correlation because we're measuring broadcast to OTHER users
-			u.correlator.RecordReceivedEvent(event.Type, event.CardID, event.ReceiverID, event.Timestamp)
+			u.correlator.RecordReceivedEvent(event)
+
+			// Real clients reload the board on a scene change, all at once
+			if u.meeting != nil && event.Type == "scene_changed" {
+				go u.refetchBoard()
+			}
+
+			// Keep every client's view of the timer to compare countdowns
+			if u.timers != nil && event.Timer != nil {
+				u.timers.RecordObservation(u.ctx.ID, event.Timestamp, *event.Timer)
+			}
+
+			// ...and the card each client ended up presenting
+			if u.present != nil && event.Selection != nil {
+				u.present.RecordSelection(u.ctx.ID, *event.Selection)
+			}
+
+			// Like the web client, pick up added, reordered and deleted columns
+			if event.Columns != nil {
+				u.ctx.SetColumnIDs(event.Columns)
+			}
+		}
+	}
+}
+
+// handlePresence answers presence pings the way the web client does and
+// hands every presence list to the tracker
+func (u *UserSimulator) handlePresence(event ReceivedEvent) {
+	if event.Type == "presence_ping" {
+		go func() {
+			err := u.api.UpdatePresence(u.boardID, "pong")
+			if u.presence != nil {
+				u.presence.RecordPong(err)
+			}
+		}()
+		return
+	}
+	if u.presence == nil {
+		return
+	}
+	if event.Presence != nil {
+		u.presence.Observe(u.ctx.ID, event.Timestamp, event.Presence)
+	}
+}
+
+// Action names used in weight tables and scenario files
+const (
+	ActionCreateCard    = "create_card"
+	ActionMoveCard      = "move_card"
+	ActionVote          = "vote"
+	ActionGroupCards    = "group_cards"
+	ActionGroupCardOnto = "group_card_onto"
+	ActionEditCard      = "edit_card"
+	ActionDeleteCard    = "delete_card"
+	ActionAddComment    = "add_comment"
+	ActionDeleteComment = "delete_comment"
+	ActionEditNotes     = "edit_notes"
+	ActionPositionCard  = "position_card"
+	ActionFlagResult    = "flag_result"
+	ActionVoteTimer     = "vote_timer"
+	ActionLoadPresent   = "load_present_data"
+	ActionSpendVote     = "spend_vote"
+
+	// Facilitator-only actions
+	ActionChangeScene     = "change_scene"
+	ActionStartTimer      = "start_timer"
+	ActionSelectCard      = "select_card"
+	ActionUngroupCard     = "ungroup_card"
+	ActionClearVotes      = "clear_votes"
+	ActionToggleAgreement = "toggle_agreement"
+	ActionCreateAgreement = "create_agreement"
+	ActionCloneBoard      = "clone_board"
+)
+
+// DefaultActionWeights is the action mix used when no scenario overrides it
+var DefaultActionWeights = map[string]int{
+	ActionCreateCard:    40, // 40% create card
+	ActionMoveCard:      15, // 15% move card
+	ActionVote:          20, // 20% vote
+	ActionGroupCards:    10, // 10% group cards
+	ActionGroupCardOnto: 5,  // 5% group card onto another
+	ActionEditCard:      5,  // 5% edit card content
+	ActionDeleteCard:    5,  // 5% delete card
+}
+
+// knownActions lists every action a weight table may reference
+var knownActions = []string{
+	ActionCreateCard,
+	ActionMoveCard,
+	ActionVote,
+	ActionGroupCards,
+	ActionGroupCardOnto,
+	ActionEditCard,
+	ActionDeleteCard,
+	ActionAddComment,
+	ActionDeleteComment,
+	ActionEditNotes,
+	ActionPositionCard,
+	ActionFlagResult,
+	ActionVoteTimer,
+	ActionLoadPresent,
+	ActionSpendVote,
+	ActionChangeScene,
+	ActionStartTimer,
+	ActionSelectCard,
+	ActionUngroupCard,
+	ActionClearVotes,
+	ActionToggleAgreement,
+	ActionCreateAgreement,
+	ActionCloneBoard,
+}
+
+// facilitatorActions lists actions that need the facilitator or admin role
+var facilitatorActions = []string{
+	ActionChangeScene,
+	ActionStartTimer,
+	ActionSelectCard,
+	ActionUngroupCard,
+	ActionClearVotes,
+	ActionToggleAgreement,
+	ActionCreateAgreement,
+	ActionCloneBoard,
+}
+
+// KnownActions returns the names of all actions a scenario may weight
+func KnownActions() []string {
+	return append([]string{}, knownActions...)
+}
+
+// IsKnownAction reports whether name is a valid action
+func IsKnownAction(name string) bool {
+	for _, known := range knownActions {
+		if known == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFacilitatorAction reports whether name needs the facilitator or admin role
+func IsFacilitatorAction(name string) bool {
+	for _, action := range facilitatorActions {
+		if action == name {
+			return true
 		}
 	}
+	return false
 }
 
 // performRandomAction performs a weighted random action
-func (u *UserSimulator) performRandomAction() {
+func (u *UserSimulator) performRandomAction() error {
 	actions := []struct {
-		weight int
+		name   string
+		flag   string // Scene flag the action needs; empty if none
 		action func() error
 	}{
-		{40, u.createCard},     // 40% create card
-		{20, u.moveCard},       // 20% move card
-		{20, u.voteOnCard},     // 20% vote
-		{10, u.groupCards},     // 10% group cards
-		{10, u.groupCardOnto},  // 10% group card onto another
+		{ActionCreateCard, "allow_add_cards", u.createCard},
+		{ActionMoveCard, "allow_move_cards", u.moveCard},
+		{ActionVote, "allow_voting", u.voteOnCard},
+		{ActionGroupCards, "allow_group_cards", u.groupCards},
+		{ActionGroupCardOnto, "allow_group_cards", u.groupCardOnto},
+		{ActionEditCard, "allow_edit_cards", u.editCard},
+		{ActionDeleteCard, "allow_edit_cards", u.deleteCard},
+		{ActionAddComment, "allow_comments", u.addComment},
+		{ActionDeleteComment, "allow_comments", u.deleteComment},
+		{ActionEditNotes, "", u.editNotes},
+		{ActionPositionCard, "", u.positionCard},
+		{ActionFlagResult, "", u.flagResult},
+		{ActionVoteTimer, "", u.voteTimer},
+		{ActionLoadPresent, "", u.loadPresentData},
+		{ActionSpendVote, "allow_voting", u.spendVote},
+		{ActionChangeScene, "", u.changeScene},
+		{ActionStartTimer, "", u.startTimer},
+		{ActionSelectCard, "", u.selectCard},
+		{ActionUngroupCard, "allow_group_cards", u.ungroupCard},
+		{ActionClearVotes, "", u.clearVotes},
+		{ActionToggleAgreement, "", u.toggleAgreement},
+		{ActionCreateAgreement, "", u.createAgreement},
+		{ActionCloneBoard, "", u.cloneBoard},
+	}
+
+	weights := u.persona.Actions
+	if len(weights) == 0 {
+		weights = DefaultActionWeights
+	}
+	weightOf := func(name, flag string) int {
+		if !u.canPerform(name, flag) {
+			return 0
+		}
+		return weights[name]
 	}
 
 	// Calculate total weight
 	totalWeight := 0
 	for _, a := range actions {
-		totalWeight += a.weight
+		totalWeight += weightOf(a.name, a.flag)
+	}
+	if totalWeight == 0 {
+		return nil
 	}
 
 	// Pick random action
 	roll := rand.Intn(totalWeight)
 	cumulative := 0
 	for _, a := range actions {
-		cumulative += a.weight
+		cumulative += weightOf(a.name, a.flag)
 		if roll < cumulative {
-			_ = a.action()
-			return
+			return a.action()
+		}
+	}
+	return nil
+}
+
+// canPerform reports whether an action is permitted right now. Without a
+// scripted meeting the current scene has every flag forced on; with one,
+// only actions the current scene allows are issued and the driver alone
+// changes scenes. Cards are only dragged while a quadrant input phase is
+// open, scorecard results only flagged once some have been collected,
+// timer votes only cast while a timer runs, present data only loaded
+// while the board presents, and session votes only spent during a round.
+func (u *UserSimulator) canPerform(name, flag string) bool {
+	switch name {
+	case ActionVoteTimer:
+		if u.timers == nil {
+			return false
 		}
+		_, running := u.timers.CurrentTimer()
+		return running
+	case ActionPositionCard:
+		return u.quadrant != nil && u.quadrant.AcceptingPositions()
+	case ActionFlagResult:
+		return u.scorecards != nil && u.scorecards.HasResults()
+	case ActionLoadPresent:
+		return u.present != nil && u.present.Presenting()
+	case ActionSpendVote:
+		return u.voting != nil
+	}
+	if u.meeting == nil {
+		return true
+	}
+	if name == ActionChangeScene {
+		return false
+	}
+	return flag == "" || u.meeting.Allows(flag)
+}
+
+// recordColumnFailure lets the churn driver count a failed action that
+// targeted a column it deleted
+func (u *UserSimulator) recordColumnFailure(action, columnID string) {
+	if u.churn != nil {
+		u.churn.RecordColumnFailure(action, columnID)
 	}
 }
 
 // createCard creates a new card
 func (u *UserSimulator) createCard() error {
-	if len(u.ctx.ColumnIDs) == 0 {
+	columnIDs := u.ctx.GetColumnIDs()
+	if len(columnIDs) == 0 {
 		return fmt.Errorf("no columns available")
 	}
 
-	randomColumn := u.ctx.ColumnIDs[rand.Intn(len(u.ctx.ColumnIDs))]
-	content := fmt.Sprintf("Test card from user %d at %s", u.ctx.ID, time.Now().Format("15:04:05"))
+	randomColumn := columnIDs[rand.Intn(len(columnIDs))]
+	nonce := u.nextNonce()
+	content := withNonce(fmt.Sprintf("Test card from user %d at %s", u.ctx.ID, time.Now().Format("15:04:05")), nonce)
 
 	// Pre-register the event with a temporary ID (we'll update it after creation)
 	// Actually, we don't know the card ID yet, so we need to record after but handle race condition differently
 
+	// The broadcast can reach other clients before the API responds
+	u.schema.ExpectCard(nonce, randomColumn, content)
 	card, err := u.api.CreateCard(u.boardID, randomColumn, content)
 	if err != nil {
+		u.recordColumnFailure(ActionCreateCard, randomColumn)
 		if u.config.Verbose {
 			fmt.Printf("User %d: create card failed: %v\n", u.ctx.ID, err)
 		}
@@ -165,7 +534,7 @@ func (u *UserSimulator) createCard() error {
 	}
 
 	// Record IMMEDIATELY after getting the ID, before any other processing
-	u.correlator.RecordSentEvent("card_created", card.ID, u.ctx.ID)
+	u.correlator.RecordTaggedEvent("card_created", card.ID, u.ctx.ID, contentTag(nonce))
 	u.ctx.AddCardID(card.ID)
 
 	if u.config.Verbose {
@@ -178,21 +547,23 @@ func (u *UserSimulator) createCard() error {
 // moveCard moves a random card
 func (u *UserSimulator) moveCard() error {
 	cardIDs := u.ctx.GetCardIDs()
-	if len(cardIDs) == 0 || len(u.ctx.ColumnIDs) == 0 {
+	columnIDs := u.ctx.GetColumnIDs()
+	if len(cardIDs) == 0 || len(columnIDs) == 0 {
 		return nil // Skip if no cards
 	}
 
 	randomCard := cardIDs[rand.Intn(len(cardIDs))]
-	randomColumn := u.ctx.ColumnIDs[rand.Intn(len(u.ctx.ColumnIDs))]
+	randomColumn := columnIDs[rand.Intn(len(columnIDs))]
 
 	if err := u.api.MoveCard(randomCard, randomColumn); err != nil {
+		u.recordColumnFailure(ActionMoveCard, randomColumn)
 		if u.config.Verbose {
 			fmt.Printf("User %d: move card failed: %v\n", u.ctx.ID, err)
 		}
 		return err
 	}
 
-	u.correlator.RecordSentEvent("card_updated", randomCard, u.ctx.ID)
+	u.correlator.RecordTaggedEvent("card_updated", randomCard, u.ctx.ID, columnTag(randomColumn))
 
 	if u.config.Verbose {
 		fmt.Printf("✅ User %d moved card %s\n", u.ctx.ID, randomCard)
@@ -223,14 +594,21 @@ func (u *UserSimulator) voteOnCard() error {
 
 	randomCard := allCards[rand.Intn(len(allCards))]
 
-	if err := u.api.VoteOnCard(randomCard); err != nil {
+	accepted, voteCount, err := u.api.VoteOnCard(randomCard)
+	if err != nil {
 		if u.config.Verbose {
 			fmt.Printf("User %d: vote failed: %v\n", u.ctx.ID, err)
 		}
 		return err
 	}
+	if !accepted {
+		if u.config.Verbose {
+			fmt.Printf("User %d: no votes left for card %s\n", u.ctx.ID, randomCard)
+		}
+		return nil
+	}
 
-	u.correlator.RecordSentEvent("vote_changed", randomCard, u.ctx.ID)
+	u.recordVote(randomCard, voteCount)
 
 	if u.config.Verbose {
 		fmt.Printf("✅ User %d voted on card %s\n", u.ctx.ID, randomCard)
@@ -239,6 +617,29 @@ func (u *UserSimulator) voteOnCard() error {
 	return nil
 }
 
+// recordVote records the broadcasts that follow an accepted vote. A scene
+// that shows votes sends everyone the card's new total and every card's
+// totals; one that only allows voting sends the card's total to the voter
+// alone and voting stats to everyone else.
+func (u *UserSimulator) recordVote(cardID string, voteCount *int) {
+	if u.showsVotes() {
+		u.correlator.RecordTaggedEvent("vote_changed", cardID, u.ctx.ID, votesTag(voteCount))
+		u.correlator.RecordSentEvent("all_votes_updated", u.boardID, u.ctx.ID)
+		return
+	}
+	u.correlator.RecordSenderEvent("vote_changed", cardID, u.ctx.ID, votesTag(voteCount))
+	u.correlator.RecordOthersEvent("voting_stats_updated", u.boardID, u.ctx.ID)
+}
+
+// showsVotes reports whether the current scene shows vote totals: the
+// meeting's scene if there is one, otherwise the flags forced onto it
+func (u *UserSimulator) showsVotes() bool {
+	if u.meeting != nil {
+		return u.meeting.Allows("show_votes")
+	}
+	return slices.Contains(u.config.SceneFlags, "show_votes")
+}
+
 // groupCards groups multiple cards
 func (u *UserSimulator) groupCards() error {
 	board, err := u.api.GetBoard(u.boardID)
@@ -320,9 +721,10 @@ func (u *UserSimulator) groupCardOnto() error {
 		return nil
 	}
 
-	// Find another card in the same column
+	// Find another card in the same column, and the group it leads or is in
 	var targetCard *Card
-	for _, column := range board.Columns {
+	var column Column
+	for _, column = range board.Columns {
 		if column.ID != ourCard.ColumnID {
 			continue
 		}
@@ -340,6 +742,12 @@ func (u *UserSimulator) groupCardOnto() error {
 	if targetCard == nil {
 		return nil
 	}
+	grouped := []string{ourCard.ID}
+	for _, card := range column.Cards {
+		if card.ID == targetCard.ID || (targetCard.GroupID != "" && card.GroupID == targetCard.GroupID && card.ID != ourCard.ID) {
+			grouped = append(grouped, card.ID)
+		}
+	}
 
 	if err := u.api.GroupCardOnto(ourCard.ID, targetCard.ID); err != nil {
 		if u.config.Verbose {
@@ -348,7 +756,10 @@ func (u *UserSimulator) groupCardOnto() error {
 		return err
 	}
 
-	u.correlator.RecordSentEvent("card_grouped_onto", ourCard.ID, u.ctx.ID)
+	// The server sends card_updated for every card in the resulting group
+	for _, cardID := range grouped {
+		u.correlator.RecordTaggedEvent("card_updated", cardID, u.ctx.ID, columnTag(ourCard.ColumnID))
+	}
 
 	if u.config.Verbose {
 		fmt.Printf("✅ User %d grouped card %s onto %s\n", u.ctx.ID, ourCard.ID, targetCard.ID)
@@ -357,21 +768,764 @@ func (u *UserSimulator) groupCardOnto() error {
 	return nil
 }
 
-// Stop stops the user simulator
-func (u *UserSimulator) Stop() {
-	close(u.ctx.StopChan)
-	if u.sse != nil {
-		u.sse.Close()
+// editCard rewrites the content of one of this user's cards
+func (u *UserSimulator) editCard() error {
+	cardIDs := u.ctx.GetCardIDs()
+	if len(cardIDs) == 0 {
+		return nil
 	}
-	u.ctx.SetConnected(false)
+
+	randomCard := cardIDs[rand.Intn(len(cardIDs))]
+	nonce := u.nextNonce()
+	content := withNonce(fmt.Sprintf("Edited by user %d at %s", u.ctx.ID, time.Now().Format("15:04:05")), nonce)
+
+	u.schema.ExpectCard(nonce, "", content)
+	if err := u.api.UpdateCard(randomCard, content); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: edit card failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordTaggedEvent("card_updated", randomCard, u.ctx.ID, contentTag(nonce))
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d edited card %s\n", u.ctx.ID, randomCard)
+	}
+
+	return nil
 }
 
-// IsConnected returns whether the user is connected
-func (u *UserSimulator) IsConnected() bool {
-	return u.ctx.Connected()
+// deleteCard deletes one of this user's cards, racing any other user still
+// voting on or grouping it
+func (u *UserSimulator) deleteCard() error {
+	cardIDs := u.ctx.GetCardIDs()
+	if len(cardIDs) == 0 {
+		return nil
+	}
+
+	randomCard := cardIDs[rand.Intn(len(cardIDs))]
+
+	if err := u.api.DeleteCard(randomCard); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: delete card failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("card_deleted", randomCard, u.ctx.ID)
+	u.ctx.RemoveCardID(randomCard)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d deleted card %s\n", u.ctx.ID, randomCard)
+	}
+
+	return nil
 }
 
-// GetID returns the user's ID
-func (u *UserSimulator) GetID() int {
-	return u.ctx.ID
+// ungroupCard takes a random grouped card out of its group
+func (u *UserSimulator) ungroupCard() error {
+	board, err := u.api.GetBoard(u.boardID)
+	if err != nil {
+		return err
+	}
+
+	var groupedCards []Card
+	for _, column := range board.Columns {
+		for _, card := range column.Cards {
+			if card.GroupID != "" {
+				card.ColumnID = column.ID
+				groupedCards = append(groupedCards, card)
+			}
+		}
+	}
+
+	if len(groupedCards) == 0 {
+		return nil
+	}
+
+	grouped := groupedCards[rand.Intn(len(groupedCards))]
+	randomCard := grouped.ID
+
+	if err := u.api.UngroupCard(randomCard); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: ungroup card failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	// The card stays in its column, which tells this update from a move.
+	// The server also updates any card left alone in the group, which is not tracked.
+	u.correlator.RecordTaggedEvent("card_updated", randomCard, u.ctx.ID, columnTag(grouped.ColumnID))
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d ungrouped card %s\n", u.ctx.ID, randomCard)
+	}
+
+	return nil
+}
+
+// changeScene switches the board to a different scene
+func (u *UserSimulator) changeScene() error {
+	board, err := u.api.GetBoard(u.boardID)
+	if err != nil {
+		return err
+	}
+
+	var candidates []Scene
+	for _, scene := range board.Scenes {
+		if scene.ID != board.CurrentSceneID {
+			candidates = append(candidates, scene)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	scene := candidates[rand.Intn(len(candidates))]
+
+	if err := u.api.ChangeScene(u.boardID, scene.ID); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: change scene failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("scene_changed", scene.ID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d changed scene to %s\n", u.ctx.ID, scene.Title)
+	}
+
+	return nil
+}
+
+// startTimer starts a countdown of one to five minutes
+func (u *UserSimulator) startTimer() error {
+	duration := 60 + rand.Intn(241)
+
+	if _, err := u.api.StartTimer(u.boardID, duration); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: start timer failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("timer_update", u.boardID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d started a %ds timer\n", u.ctx.ID, duration)
+	}
+
+	return nil
+}
+
+// selectCard selects a random card for presentation in the current scene
+func (u *UserSimulator) selectCard() error {
+	board, err := u.api.GetBoard(u.boardID)
+	if err != nil {
+		return err
+	}
+
+	var allCards []string
+	for _, column := range board.Columns {
+		for _, card := range column.Cards {
+			allCards = append(allCards, card.ID)
+		}
+	}
+
+	if len(allCards) == 0 || board.CurrentSceneID == "" {
+		return nil
+	}
+
+	randomCard := allCards[rand.Intn(len(allCards))]
+
+	if err := u.api.SelectCard(board.CurrentSceneID, randomCard); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: select card failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordTaggedEvent("update_presentation", board.CurrentSceneID, u.ctx.ID, cardTag(randomCard))
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d selected card %s\n", u.ctx.ID, randomCard)
+	}
+
+	return nil
+}
+
+// clearVotes clears every vote on the board
+func (u *UserSimulator) clearVotes() error {
+	if err := u.api.ClearVotes(u.boardID); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: clear votes failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("board_updated", u.boardID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d cleared votes\n", u.ctx.ID)
+	}
+
+	return nil
+}
+
+// cloneBoard clones the board under test into a new board, the way the
+// clone dialog does: snapshot the source, create an empty board, find the
+// source among the offered clone sources, clone, and compare the result.
+// Only the clone request itself is timed; failures are listed in the report.
+func (u *UserSimulator) cloneBoard() error {
+	source, err := u.api.GetBoard(u.boardID)
+	if err != nil {
+		return err
+	}
+	target, err := u.prepareClone(source)
+	if err != nil {
+		u.clones.Record(time.Time{}, time.Time{}, 0, nil, err)
+		return err
+	}
+
+	started := time.Now()
+	err = u.api.CloneBoard(target.ID, source.ID)
+	finished := time.Now()
+	if err != nil {
+		u.clones.Record(started, finished, 0, nil, err)
+		return err
+	}
+
+	clone, err := u.api.GetBoard(target.ID)
+	if err != nil {
+		err = fmt.Errorf("fetch clone: %w", err)
+		u.clones.Record(started, finished, 0, nil, err)
+		return err
+	}
+	mismatches := compareClone(source, clone)
+	u.clones.Record(started, finished, len(source.Cards), mismatches, nil)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d cloned the board in %v (%d differences)\n",
+			u.ctx.ID, FormatDuration(finished.Sub(started)), len(mismatches))
+	}
+
+	return nil
+}
+
+// prepareClone creates the empty board to clone into and checks that the
+// clone dialog would offer source for it
+func (u *UserSimulator) prepareClone(source *BoardState) (*BoardState, error) {
+	target, err := u.api.CreateBoard(fmt.Sprintf("Clone by user %d at %s", u.ctx.ID, time.Now().Format("15:04:05")), source.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("create target board: %w", err)
+	}
+
+	sources, err := u.api.GetCloneSources(target.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range sources {
+		if candidate.ID == source.ID {
+			return target, nil
+		}
+	}
+	return nil, fmt.Errorf("board %s not among %d clone sources", source.ID, len(sources))
+}
+
+// addComment comments on a random card on the board
+func (u *UserSimulator) addComment() error {
+	cardID, err := u.randomBoardCard()
+	if err != nil || cardID == "" {
+		return err
+	}
+
+	content := fmt.Sprintf("Comment from user %d at %s", u.ctx.ID, time.Now().Format("15:04:05"))
+	comment, err := u.api.AddComment(cardID, content)
+	if err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: add comment failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("comment_added", comment.ID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d commented on card %s\n", u.ctx.ID, cardID)
+	}
+
+	return nil
+}
+
+// deleteComment deletes a random comment on a random card. Participants
+// only delete their own; facilitators may delete anyone's.
+func (u *UserSimulator) deleteComment() error {
+	cardID, err := u.randomBoardCard()
+	if err != nil || cardID == "" {
+		return err
+	}
+
+	comments, err := u.api.GetComments(cardID)
+	if err != nil {
+		return err
+	}
+	var deletable []Comment
+	for _, comment := range comments {
+		if comment.UserID == u.ctx.UserID || u.persona.CanFacilitate() {
+			deletable = append(deletable, comment)
+		}
+	}
+	if len(deletable) == 0 {
+		return nil
+	}
+	comment := deletable[rand.Intn(len(deletable))]
+
+	if err := u.api.DeleteComment(comment.ID); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: delete comment failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	// The server announces the deletion with an update_presentation carrying deleted_comment_id
+	u.correlator.RecordSentEvent("update_presentation", comment.ID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d deleted comment %s\n", u.ctx.ID, comment.ID)
+	}
+
+	return nil
+}
+
+// toggleAgreement promotes or demotes a random comment on a random card
+func (u *UserSimulator) toggleAgreement() error {
+	cardID, err := u.randomBoardCard()
+	if err != nil || cardID == "" {
+		return err
+	}
+
+	comments, err := u.api.GetComments(cardID)
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+	comment := comments[rand.Intn(len(comments))]
+
+	if err := u.api.ToggleAgreement(comment.ID, !comment.IsAgreement); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: toggle agreement failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("update_presentation", comment.ID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d set agreement=%t on comment %s\n", u.ctx.ID, !comment.IsAgreement, comment.ID)
+	}
+
+	return nil
+}
+
+// createAgreement adds a standalone agreement to the board
+func (u *UserSimulator) createAgreement() error {
+	content := fmt.Sprintf("Agreement from user %d at %s", u.ctx.ID, time.Now().Format("15:04:05"))
+	if err := u.api.CreateAgreement(u.boardID, content); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: create agreement failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("agreements_updated", u.boardID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d created an agreement\n", u.ctx.ID)
+	}
+
+	return nil
+}
+
+// editNotes takes the notes lock on a contended card, edits for the hold
+// time and saves, checking that nobody else wrote in between
+func (u *UserSimulator) editNotes() error {
+	cardID := u.notes.PickCard()
+	if cardID == "" {
+		var err error
+		if cardID, err = u.randomBoardCard(); err != nil || cardID == "" {
+			return err
+		}
+	}
+
+	started := time.Now()
+	for attempt := 1; ; attempt++ {
+		requestStart := time.Now()
+		acquired, err := u.api.AcquireNotesLock(cardID)
+		u.notes.RecordAttempt(time.Since(requestStart), acquired, err)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			break
+		}
+		if attempt == notesMaxAttempts {
+			u.notes.RecordAbandoned()
+			return nil
+		}
+		backoff := time.Duration(attempt) * notesRetryBackoff
+		time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff))))
+	}
+	// Every lock change and save is announced for the card alone, so the
+	// card is all that tells these sends from other presentation updates
+	u.notes.RecordAcquired(cardID, u.ctx.ID, time.Since(started))
+	u.correlator.RecordTaggedEvent("update_presentation", u.sse.CurrentScene(), u.ctx.ID, cardTag(cardID))
+
+	before, err := u.cardNotes(cardID)
+	if err != nil {
+		u.notes.Release(cardID, u.ctx.ID)
+		if released, _ := u.api.ReleaseNotesLock(cardID); released {
+			u.correlator.RecordTaggedEvent("update_presentation", u.sse.CurrentScene(), u.ctx.ID, cardTag(cardID))
+		}
+		return err
+	}
+
+	time.Sleep(u.notes.Hold())
+
+	after, err := u.cardNotes(cardID)
+	if err == nil && after != before {
+		u.notes.RecordInterleaved(cardID)
+	}
+
+	// Saving releases the lock on the server, so stop claiming it first
+	u.notes.Release(cardID, u.ctx.ID)
+	content := fmt.Sprintf("Notes by user %d at %s", u.ctx.ID, time.Now().Format("15:04:05.000"))
+	if err := u.api.UpdateNotes(cardID, content); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: update notes failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordTaggedEvent("update_presentation", u.sse.CurrentScene(), u.ctx.ID, cardTag(cardID))
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d edited notes on card %s\n", u.ctx.ID, cardID)
+	}
+
+	return nil
+}
+
+// cardNotes reads a card's current notes from the board
+func (u *UserSimulator) cardNotes(cardID string) (string, error) {
+	board, err := u.api.GetBoard(u.boardID)
+	if err != nil {
+		return "", err
+	}
+	for _, column := range board.Columns {
+		for _, card := range column.Cards {
+			if card.ID == cardID {
+				return card.Notes, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// positionCard drags one of the quadrant's cards to a random spot
+func (u *UserSimulator) positionCard() error {
+	sceneID, cardID := u.quadrant.PickCard()
+	x, y := randomQuadrantPosition(), randomQuadrantPosition()
+
+	started := time.Now()
+	err := u.api.SetQuadrantPosition(cardID, sceneID, x, y)
+	u.quadrant.RecordPosition(time.Since(started), err)
+	if err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: set quadrant position failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d placed card %s at (%d, %d)\n", u.ctx.ID, cardID, x, y)
+	}
+
+	return nil
+}
+
+// flagResult flags a random scorecard result for discussion, turning it into a card
+func (u *UserSimulator) flagResult() error {
+	resultID := u.scorecards.PickResult()
+	columnIDs := u.ctx.GetColumnIDs()
+	if resultID == "" || len(columnIDs) == 0 {
+		return nil
+	}
+	columnID := columnIDs[rand.Intn(len(columnIDs))]
+
+	started := time.Now()
+	err := u.api.FlagScorecardResult(resultID, columnID)
+	u.scorecards.RecordFlag(time.Since(started), err)
+	if err != nil {
+		u.recordColumnFailure(ActionFlagResult, columnID)
+		if u.config.Verbose {
+			fmt.Printf("User %d: flag scorecard result failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.correlator.RecordSentEvent("scorecard_result_flagged", resultID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d flagged scorecard result %s\n", u.ctx.ID, resultID)
+	}
+
+	return nil
+}
+
+// voteTimer votes for more time or to finish on the running timer
+func (u *UserSimulator) voteTimer() error {
+	timerID, running := u.timers.CurrentTimer()
+	if !running {
+		return nil
+	}
+	choice := u.timers.PickChoice()
+
+	if err := u.api.VoteTimer(u.boardID, timerID, choice); err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: timer vote failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	u.timers.RecordVote(u.ctx.ID, timerID, choice)
+	u.correlator.RecordSentEvent("timer_update", u.boardID, u.ctx.ID)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d voted %s on the timer\n", u.ctx.ID, choice)
+	}
+
+	return nil
+}
+
+// loadPresentData reloads the board as present mode shows it, like a
+// participant opening the presentation
+func (u *UserSimulator) loadPresentData() error {
+	started := time.Now()
+	data, err := u.api.GetPresentData(u.boardID)
+	u.present.RecordLoad(time.Since(started), err)
+	if err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: load present data failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d loaded present data (%d cards)\n", u.ctx.ID, len(data.VisibleCards))
+	}
+
+	return nil
+}
+
+// spendVote reads the user's remaining votes and votes on one of the
+// session's cards, whether or not any are left: with none left the server
+// must turn the vote away
+func (u *UserSimulator) spendVote() error {
+	release, open := u.voting.Begin()
+	if !open {
+		return nil
+	}
+	defer release()
+
+	// Open-model pacing can run two of this user's actions at once, and both
+	// would read the same last vote as free
+	u.voteMu.Lock()
+	defer u.voteMu.Unlock()
+
+	votes, err := u.api.GetUserVotes(u.boardID)
+	if err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: read votes failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+	withinAllocation := votes.Remaining() > 0
+	cardID := u.voting.PickCard()
+
+	accepted, voteCount, err := u.api.VoteOnCard(cardID)
+	if err != nil {
+		if u.config.Verbose {
+			fmt.Printf("User %d: vote failed: %v\n", u.ctx.ID, err)
+		}
+		return err
+	}
+	u.voting.RecordVote(cardID, withinAllocation, accepted)
+	if !accepted {
+		if u.config.Verbose {
+			fmt.Printf("User %d: vote on card %s rejected (%d/%d spent)\n",
+				u.ctx.ID, cardID, votes.Cast(), votes.Stats.MaxVotesPerUser)
+		}
+		return nil
+	}
+
+	u.recordVote(cardID, voteCount)
+
+	if u.config.Verbose {
+		fmt.Printf("✅ User %d spent vote %d/%d on card %s\n",
+			u.ctx.ID, votes.Cast()+1, votes.Stats.MaxVotesPerUser, cardID)
+	}
+
+	return nil
+}
+
+// answerHealthSurvey waits for start, submits this round's answer to every
+// question at once (the first one twice, like a double click), then polls
+// until its own completion status and the shared results catch up
+func (u *UserSimulator) answerHealthSurvey(round *healthRound, start time.Time) healthUserResult {
+	var result healthUserResult
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	time.Sleep(time.Until(start))
+
+	submit := func(q HealthQuestion, duplicate bool) {
+		defer wg.Done()
+		requestStart := time.Now()
+		err := u.api.SubmitHealthResponse(q.ID, round.ratings[q.ID])
+		latency := time.Since(requestStart)
+
+		if !duplicate {
+			round.recordAnswer(q.ID, u.ctx.ID, err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		result.submitLatencies = append(result.submitLatencies, latency)
+		if err != nil {
+			result.failures++
+			result.duplicateFailed = result.duplicateFailed || duplicate
+			if u.config.Verbose {
+				fmt.Printf("User %d: health response failed: %v\n", u.ctx.ID, err)
+			}
+		}
+	}
+
+	for i, q := range round.questions {
+		wg.Add(1)
+		go submit(q, false)
+		if i == 0 {
+			wg.Add(1)
+			go submit(q, true)
+		}
+	}
+	wg.Wait()
+	round.submitted.Done()
+	u.ctx.RecordAction(nil)
+
+	submitted := time.Now()
+	deadline := submitted.Add(healthPollTimeout)
+	completed := false
+	for time.Now().Before(deadline) {
+		if !completed {
+			status, err := u.api.GetCompletionStatus(round.sceneID)
+			if err == nil && status.Completed {
+				completed = true
+				result.completionLag = time.Since(submitted)
+			}
+		}
+		if completed {
+			results, err := u.api.GetHealthResults(round.sceneID)
+			if err == nil && round.converged(results) {
+				result.resultsLag = time.Since(submitted)
+				result.converged = true
+				break
+			}
+		}
+		time.Sleep(healthPollInterval)
+	}
+
+	return result
+}
+
+// randomBoardCard returns a random card from the current board state, or "" if it has none
+func (u *UserSimulator) randomBoardCard() (string, error) {
+	board, err := u.api.GetBoard(u.boardID)
+	if err != nil {
+		return "", err
+	}
+
+	var allCards []string
+	for _, column := range board.Columns {
+		for _, card := range column.Cards {
+			allCards = append(allCards, card.ID)
+		}
+	}
+
+	if len(allCards) == 0 {
+		return "", nil
+	}
+	return allCards[rand.Intn(len(allCards))], nil
+}
+
+// refetchBoard reloads the board the way the web client does after a scene change
+func (u *UserSimulator) refetchBoard() {
+	scene, _ := u.meeting.CurrentScene()
+	started := time.Now()
+	board, err := u.api.GetBoard(u.boardID)
+	latency := time.Since(started)
+
+	// The event can beat the driver's own bookkeeping, so trust the board
+	if err == nil {
+		for _, s := range board.Scenes {
+			if s.ID == board.CurrentSceneID {
+				scene = s
+			}
+		}
+	}
+	u.meeting.RecordRefetch(scene.Title, latency, err)
+
+	if err != nil && u.config.Verbose {
+		fmt.Printf("User %d: board refetch failed: %v\n", u.ctx.ID, err)
+	}
+}
+
+// Persona returns the persona this user plays
+func (u *UserSimulator) Persona() Persona {
+	return u.persona
+}
+
+// Stop stops the user simulator
+func (u *UserSimulator) Stop() {
+	close(u.ctx.StopChan)
+	if u.sse != nil {
+		u.sse.Close()
+		if u.presence != nil {
+			u.presence.Leave(u.ctx.UserID)
+		}
+	}
+	u.ctx.SetConnected(false)
+}
+
+// IsConnected returns whether the user is connected
+func (u *UserSimulator) IsConnected() bool {
+	return u.ctx.Connected()
+}
+
+// Replica returns the user's copy of the board, or nil if none is kept
+func (u *UserSimulator) Replica() *BoardReplica {
+	return u.replica
+}
+
+// GetID returns the user's ID
+func (u *UserSimulator) GetID() int {
+	return u.ctx.ID
+}
+
+// nextNonce returns a value no other action in the run writes
+func (u *UserSimulator) nextNonce() string {
+	return fmt.Sprintf("%d.%d", u.ctx.ID, u.nonces.Add(1))
 }
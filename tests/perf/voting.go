@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// votingRound is one stretch of voting between clears
+type votingRound struct {
+	number          int
+	allocation      int
+	accepted        map[string]int // card -> votes the server accepted
+	rejected        int
+	overAllocated   int
+	unexpected      int
+	serverTotal     int
+	mismatched      int
+	increaseEventID string
+	clearEventID    string
+	err             error
+}
+
+// VotingSession runs dot voting the way a facilitator does: open a round
+// with a fixed allocation, hand out one more vote at half time, then check
+// the totals and clear the board. Participants spend votes until they run
+// out and keep trying afterwards, so every vote the server accepts or turns
+// away can be held against the allocation the user had at the time.
+//
+// Votes hold the gate shared and the facilitator holds it exclusively, so
+// no vote is in flight while the allocation changes or the totals are read.
+// Only votes cast through the session are counted, so the totals are
+// compared on the session's cards alone.
+type VotingSession struct {
+	api        *APIClient
+	boardID    string
+	cardIDs    []string
+	allocation int
+	round      time.Duration
+	correlator *EventCorrelator
+	gate       sync.RWMutex
+	rounds     []*votingRound
+	current    *votingRound // nil between rounds
+	report     VotingReport
+	mu         sync.Mutex
+}
+
+// NewVotingSession creates a driver running rounds of length round on the
+// board, each opening with allocation votes per user
+func NewVotingSession(api *APIClient, boardID string, cardIDs []string, allocation int, round time.Duration,
+	correlator *EventCorrelator) *VotingSession {
+	return &VotingSession{
+		api:        api,
+		boardID:    boardID,
+		cardIDs:    cardIDs,
+		allocation: allocation,
+		round:      round,
+		correlator: correlator,
+		report:     VotingReport{Cards: len(cardIDs), Allocation: allocation, Round: round},
+	}
+}
+
+// Begin holds the gate for one vote, reporting false (and holding nothing)
+// when no round is open. Callers must call release once the vote is recorded.
+func (v *VotingSession) Begin() (release func(), ok bool) {
+	v.gate.RLock()
+	v.mu.Lock()
+	open := v.current != nil
+	v.mu.Unlock()
+	if !open {
+		v.gate.RUnlock()
+		return nil, false
+	}
+	return v.gate.RUnlock, true
+}
+
+// PickCard returns a random card to vote on
+func (v *VotingSession) PickCard() string {
+	return v.cardIDs[rand.Intn(len(v.cardIDs))]
+}
+
+// RecordVote stores the server's answer to a vote on cardID, cast with or
+// without votes left in the user's allocation
+func (v *VotingSession) RecordVote(cardID string, withinAllocation, accepted bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	round := v.current
+	if round == nil {
+		return
+	}
+	switch {
+	case accepted:
+		round.accepted[cardID]++
+		if !withinAllocation {
+			round.overAllocated++
+		}
+	case withinAllocation:
+		round.unexpected++
+	default:
+		round.rejected++
+	}
+}
+
+// Run opens, extends, checks and clears one round after another until
+// stopChan closes, checking the last round's totals on the way out
+func (v *VotingSession) Run(stopChan <-chan bool) {
+	for number := 1; ; number++ {
+		round, done := v.runRound(number, stopChan)
+		if round.err != nil {
+			PrintWarning("Voting", fmt.Sprintf("Round %d: %v", number, round.err))
+		} else {
+			PrintInfo("Voting", fmt.Sprintf("Round %d: %d votes accepted (allocation %d), %d extra rejected, %d over allocation, %d cards mismatched",
+				number, round.acceptedVotes(), round.allocation, round.rejected, round.overAllocated, round.mismatched))
+		}
+		if done {
+			return
+		}
+	}
+}
+
+// runRound runs one round, reporting whether the run should stop afterwards
+func (v *VotingSession) runRound(number int, stopChan <-chan bool) (*votingRound, bool) {
+	round := &votingRound{number: number, allocation: v.allocation, accepted: make(map[string]int)}
+	v.mu.Lock()
+	v.rounds = append(v.rounds, round)
+	v.mu.Unlock()
+
+	// The previous clear left the allocation at zero
+	v.gate.Lock()
+	err := v.api.UpdateBoard(v.boardID, map[string]interface{}{"votingAllocation": v.allocation})
+	if err == nil {
+		v.correlator.RecordSentEvent("board_updated", v.boardID, 0)
+		v.mu.Lock()
+		v.current = round
+		v.mu.Unlock()
+	}
+	v.gate.Unlock()
+	if err != nil {
+		round.err = fmt.Errorf("set allocation: %w", err)
+		return round, waitOrStop(v.round, stopChan)
+	}
+
+	if waitOrStop(v.round/2, stopChan) {
+		v.close(round, false)
+		return round, true
+	}
+	v.increase(round)
+
+	if waitOrStop(v.round-v.round/2, stopChan) {
+		v.close(round, false)
+		return round, true
+	}
+	v.close(round, true)
+	return round, false
+}
+
+// increase hands every user one more vote
+func (v *VotingSession) increase(round *votingRound) {
+	v.gate.Lock()
+	defer v.gate.Unlock()
+
+	allocation, err := v.api.IncreaseVoteAllocation(v.boardID)
+	if err != nil {
+		round.err = fmt.Errorf("increase allocation: %w", err)
+		return
+	}
+	v.correlator.RecordSentEvent("board_updated", v.boardID, 0)
+	eventID := v.correlator.RecordSentEvent("all_votes_updated", v.boardID, 0)
+	v.mu.Lock()
+	round.allocation = allocation
+	round.increaseEventID = eventID
+	v.mu.Unlock()
+}
+
+// close ends the round, comparing the server's totals with the accepted
+// votes and, unless the run is stopping, clearing the board for the next one
+func (v *VotingSession) close(round *votingRound, clear bool) {
+	v.gate.Lock()
+	defer v.gate.Unlock()
+	v.mu.Lock()
+	v.current = nil
+	v.mu.Unlock()
+
+	if err := v.check(round); err != nil {
+		round.err = err
+		return
+	}
+	if !clear {
+		return
+	}
+	if err := v.api.ClearVotes(v.boardID); err != nil {
+		round.err = fmt.Errorf("clear votes: %w", err)
+		return
+	}
+	v.correlator.RecordSentEvent("board_updated", v.boardID, 0)
+	eventID := v.correlator.RecordSentEvent("all_votes_updated", v.boardID, 0)
+	v.mu.Lock()
+	round.clearEventID = eventID
+	v.mu.Unlock()
+}
+
+// check reads the board's vote totals and counts the cards that disagree
+// with the votes the server accepted
+func (v *VotingSession) check(round *votingRound) error {
+	stats, err := v.api.GetVotingStats(v.boardID)
+	if err != nil {
+		return fmt.Errorf("read voting stats: %w", err)
+	}
+	votes, err := v.api.GetUserVotes(v.boardID)
+	if err != nil {
+		return fmt.Errorf("read vote totals: %w", err)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	round.serverTotal = stats.TotalVotesCast
+	for _, cardID := range v.cardIDs {
+		if votes.AllVotesByCard[cardID] != round.accepted[cardID] {
+			round.mismatched++
+		}
+	}
+	return nil
+}
+
+// acceptedVotes totals the votes the server accepted in the round
+func (r *votingRound) acceptedVotes() int {
+	total := 0
+	for _, votes := range r.accepted {
+		total += votes
+	}
+	return total
+}
+
+// Report returns every round's vote counts and totals check, along with
+// delivery of the all_votes_updated sent for each increase and clear
+func (v *VotingSession) Report() *VotingReport {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	report := v.report
+	for _, round := range v.rounds {
+		roundReport := VotingRound{
+			Round:             round.number,
+			Allocation:        round.allocation,
+			Accepted:          round.acceptedVotes(),
+			Rejected:          round.rejected,
+			OverAllocated:     round.overAllocated,
+			UnexpectedRejects: round.unexpected,
+			ServerTotal:       round.serverTotal,
+			MismatchedCards:   round.mismatched,
+		}
+		if round.err != nil {
+			roundReport.Error = round.err.Error()
+		}
+		if round.increaseEventID != "" {
+			roundReport.IncreaseExpected, roundReport.IncreaseReceived, _ = v.correlator.DeliveryFor(round.increaseEventID)
+		}
+		if round.clearEventID != "" {
+			roundReport.ClearExpected, roundReport.ClearReceived, roundReport.ClearFanOut = v.correlator.DeliveryFor(round.clearEventID)
+		}
+		report.Rounds = append(report.Rounds, roundReport)
+	}
+	return &report
+}
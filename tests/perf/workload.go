@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Workloads decide what the run does beyond each user's own action mix
+const (
+	// WorkloadRandom runs every user's weighted action mix on a scene with all flags forced on
+	WorkloadRandom = "random"
+	// WorkloadLifecycle walks the board through each of its scenes like a real meeting
+	WorkloadLifecycle = "lifecycle"
+	// WorkloadNotes has users compete for the edit lock on a few shared cards
+	WorkloadNotes = "notes"
+	// WorkloadHealth has every user answer a health survey at the same instant
+	WorkloadHealth = "health"
+	// WorkloadQuadrant has every user drag cards around a quadrant scene between consensus rounds
+	WorkloadQuadrant = "quadrant"
+	// WorkloadScorecard attaches scorecards and re-collects their data while users flag results
+	WorkloadScorecard = "scorecard"
+	// WorkloadTimer runs countdowns that participants vote to extend
+	WorkloadTimer = "timer"
+	// WorkloadPresent steps through a present mode scene's cards while users follow along
+	WorkloadPresent = "present"
+	// WorkloadVoting has users spend their votes and more while the facilitator changes the allocation
+	WorkloadVoting = "voting"
+)
+
+// knownWorkloads lists every workload -workload accepts
+var knownWorkloads = []string{
+	WorkloadRandom,
+	WorkloadLifecycle,
+	WorkloadNotes,
+	WorkloadHealth,
+	WorkloadQuadrant,
+	WorkloadScorecard,
+	WorkloadTimer,
+	WorkloadPresent,
+	WorkloadVoting,
+}
+
+// workloadActions is the one action every user of a workload takes when no
+// action weights are configured
+var workloadActions = map[string]string{
+	// Everyone in the notes workload fights over the lock
+	WorkloadNotes: ActionEditNotes,
+	// Everyone in the quadrant workload drags cards
+	WorkloadQuadrant: ActionPositionCard,
+	// Everyone in the scorecard workload flags results
+	WorkloadScorecard: ActionFlagResult,
+	// Everyone in the timer workload votes on the countdown
+	WorkloadTimer: ActionVoteTimer,
+	// Everyone in the present workload reloads the presentation
+	WorkloadPresent: ActionLoadPresent,
+	// Everyone in the voting workload spends votes
+	WorkloadVoting: ActionSpendVote,
+}
+
+// ValidateWorkload checks that name is a supported workload
+func ValidateWorkload(name string) error {
+	for _, known := range knownWorkloads {
+		if known == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown workload %q (use %s)", name, strings.Join(knownWorkloads, ", "))
+}